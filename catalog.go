@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+type catalogEntry struct {
+	// message is the message as it is written in the file.
+	message *i18n.Message
+	// prepared is the message with the $t(id) references expanded with the default delimiters,
+	// see prepareMessages. The references are found in it.
+	prepared *i18n.Message
+	path     string
+}
+
+// catalog keeps the raw messages loaded to the bundle, which does not expose them.
+type catalog struct {
+	entries map[language.Tag]map[string]*catalogEntry
+	// leftDelim and rightDelim are the default delimiters of the messages set by WithDelimiters.
+	leftDelim  string
+	rightDelim string
+}
+
+func newCatalog() *catalog {
+	return &catalog{entries: make(map[language.Tag]map[string]*catalogEntry)}
+}
+
+func (c *catalog) add(file, prepared *i18n.MessageFile) {
+	if c.entries[file.Tag] == nil {
+		c.entries[file.Tag] = make(map[string]*catalogEntry)
+	}
+	for i, message := range file.Messages {
+		c.entries[file.Tag][message.ID] = &catalogEntry{message: message, prepared: prepared.Messages[i], path: file.Path}
+	}
+}
+
+// preparedMessages returns the prepared messages of the language.
+func (c *catalog) preparedMessages(tag language.Tag) []*i18n.Message {
+	messages := make([]*i18n.Message, 0, len(c.entries[tag]))
+	for _, entry := range c.entries[tag] {
+		messages = append(messages, entry.prepared)
+	}
+	return messages
+}
+
+// catalogDelims returns the default delimiters of the messages set by WithDelimiters.
+func catalogDelims() (string, string) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return messageCatalog.leftDelim, messageCatalog.rightDelim
+}
+
+// readTranslationFiles parses the translation files without adding them to a bundle.
+func readTranslationFiles(config *config) ([]*i18n.MessageFile, error) {
+	var files []*i18n.MessageFile
+	for _, path := range config.translationFiles {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := i18n.ParseMessageFileBytes(buf, path, config.unmarshalFuncMap)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	for _, translationFSFile := range config.translationFSFiles {
+		for _, path := range translationFSFile.paths {
+			buf, err := fs.ReadFile(translationFSFile.fs, path)
+			if err != nil {
+				return nil, err
+			}
+			file, err := i18n.ParseMessageFileBytes(buf, path, config.unmarshalFuncMap)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// addMessageFiles adds the messages of the files to the bundle and the catalog. It returns the prepared files.
+//
+// The bundle keeps the $t(id) references, which are expanded by the template parser with the delimiters
+// the message is rendered with.
+func addMessageFiles(b *i18n.Bundle, c *catalog, files []*i18n.MessageFile) ([]*i18n.MessageFile, error) {
+	prepared := prepareMessages(files, c.leftDelim, c.rightDelim)
+	for i, file := range files {
+		if err := b.AddMessages(file.Tag, file.Messages...); err != nil {
+			return nil, err
+		}
+		c.add(file, prepared[i])
+	}
+	return prepared, nil
+}
@@ -0,0 +1,261 @@
+// Command i18n manages the translation files of the i18n package.
+//
+// The fill command translates the messages of the default language that are missing in a language with a machine
+// translation provider and writes them to the translation file of the language. The drafts are marked with the
+// needs-review description. The drafts are appended to the file of the language, so its messages, comments and
+// formatting are kept as they are.
+//
+// The check command loads the translation files and prints the problems found by i18n.Validate, e.g. the references
+// to missing messages and the translations longer than the max length of the metadata. It exits with a non-zero
+// status when there are problems, so it can be run in CI.
+//
+// Usage:
+//
+//	i18n fill --to=ja [--from=en] [--provider=fake] [--out=locales/ja.yaml] locales/en.yaml locales/id.yaml
+//	i18n check [--from=en] [--info=locales/info.yaml] locales/en.yaml locales/id.yaml
+//
+// The fake provider prefixes the texts with the language and needs no network, e.g. for tests.
+// Other providers implement i18n.Translator and call i18n.Fill.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmadfaizk/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+const usage = `usage:
+  i18n fill --to=<language> [--from=en] [--provider=fake] [--out=<file>] <files>...
+  i18n check [--from=en] [--info=<file>] <files>...`
+
+var providers = map[string]i18n.Translator{
+	"fake": i18n.FakeTranslator{},
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "i18n:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	switch args[0] {
+	case "fill":
+		return fill(ctx, args[1:], stdout)
+	case "check":
+		return check(args[1:], stdout)
+	default:
+		return errors.New(usage)
+	}
+}
+
+func fill(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("fill", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	to := flags.String("to", "", "language to fill")
+	from := flags.String("from", "en", "default language")
+	provider := flags.String("provider", "fake", "machine translation provider")
+	out := flags.String("out", "", "file to write, defaults to the file of the language")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *to == "" || flags.NArg() == 0 {
+		return errors.New(usage)
+	}
+	toTag, err := language.Parse(*to)
+	if err != nil {
+		return err
+	}
+	fromTag, err := language.Parse(*from)
+	if err != nil {
+		return err
+	}
+	translator, ok := providers[*provider]
+	if !ok {
+		return fmt.Errorf("unknown provider %q", *provider)
+	}
+
+	err = i18n.Init(fromTag,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithUnmarshalFunc("yml", yaml.Unmarshal),
+		i18n.WithTranslationFile(flags.Args()...),
+	)
+	if err != nil {
+		return err
+	}
+	drafts, err := i18n.Fill(ctx, translator, toTag)
+	if err != nil {
+		return err
+	}
+
+	path := *out
+	if messages := i18n.Messages(toTag); path == "" && len(messages) > 0 {
+		path = messages[0].Path
+	}
+	if path == "" {
+		first := flags.Arg(0)
+		path = filepath.Join(filepath.Dir(first), toTag.String()+filepath.Ext(first))
+	}
+
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	buf, err := marshalMessages(drafts, format)
+	if err != nil {
+		return err
+	}
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if len(drafts) > 0 && len(bytes.TrimSpace(existing)) > 0 {
+		buf, err = appendMessages(existing, buf, format)
+		if err != nil {
+			return err
+		}
+	} else if len(existing) > 0 {
+		buf = existing
+	}
+	if err := os.WriteFile(path, buf, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "filled %d messages in %s\n", len(drafts), path)
+	return nil
+}
+
+func check(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	from := flags.String("from", "en", "default language")
+	info := flags.String("info", "", "file of the message metadata")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New(usage)
+	}
+	fromTag, err := language.Parse(*from)
+	if err != nil {
+		return err
+	}
+
+	opts := []i18n.Option{
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithUnmarshalFunc("yml", yaml.Unmarshal),
+		i18n.WithTranslationFile(flags.Args()...),
+	}
+	if *info != "" {
+		opts = append(opts, i18n.WithMessageInfoFile(*info))
+	}
+	if err := i18n.Init(fromTag, opts...); err != nil {
+		return err
+	}
+
+	problems := i18n.Validate()
+	for _, problem := range problems {
+		fmt.Fprintln(stdout, problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("found %d problems", len(problems))
+	}
+	fmt.Fprintln(stdout, "no problems found")
+	return nil
+}
+
+// fileMessage is a message in the format of the go-i18n translation files.
+type fileMessage struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	LeftDelim   string `json:"leftDelim,omitempty" yaml:"leftDelim,omitempty"`
+	RightDelim  string `json:"rightDelim,omitempty" yaml:"rightDelim,omitempty"`
+	Zero        string `json:"zero,omitempty" yaml:"zero,omitempty"`
+	One         string `json:"one,omitempty" yaml:"one,omitempty"`
+	Two         string `json:"two,omitempty" yaml:"two,omitempty"`
+	Few         string `json:"few,omitempty" yaml:"few,omitempty"`
+	Many        string `json:"many,omitempty" yaml:"many,omitempty"`
+	Other       string `json:"other,omitempty" yaml:"other,omitempty"`
+}
+
+// marshalMessages marshals the messages to a translation file. A message with only the other form and
+// no description is written as a string.
+func marshalMessages(messages []i18n.Message, format string) ([]byte, error) {
+	file := make(map[string]any, len(messages))
+	for _, m := range messages {
+		message := fileMessage{
+			Description: m.Description,
+			LeftDelim:   m.LeftDelim,
+			RightDelim:  m.RightDelim,
+			Zero:        m.Zero,
+			One:         m.One,
+			Two:         m.Two,
+			Few:         m.Few,
+			Many:        m.Many,
+			Other:       m.Other,
+		}
+		if message == (fileMessage{Other: m.Other}) {
+			file[m.ID] = m.Other
+			continue
+		}
+		file[m.ID] = message
+	}
+
+	switch format {
+	case "yaml", "yml":
+		return yaml.Marshal(file)
+	case "json":
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported file format %q", format)
+	}
+}
+
+// appendMessages appends the marshaled messages to the content of a translation file without decoding it.
+func appendMessages(content, messages []byte, format string) ([]byte, error) {
+	switch format {
+	case "yaml", "yml":
+		if !bytes.HasSuffix(content, []byte("\n")) {
+			content = append(content, '\n')
+		}
+		return append(content, messages...), nil
+	case "json":
+		body := bytes.TrimRight(content, " \t\r\n")
+		if !bytes.HasSuffix(body, []byte("}")) {
+			return nil, errors.New("the translation file is not a JSON object")
+		}
+		body = bytes.TrimRight(body[:len(body)-1], " \t\r\n")
+		entries := bytes.TrimSuffix(bytes.TrimPrefix(bytes.TrimSpace(messages), []byte("{")), []byte("}"))
+		entries = bytes.Trim(entries, "\n")
+
+		var buf bytes.Buffer
+		buf.Write(body)
+		if !bytes.HasSuffix(body, []byte("{")) {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+		buf.Write(entries)
+		buf.WriteString("\n}\n")
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported file format %q", format)
+	}
+}
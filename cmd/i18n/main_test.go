@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const enYAML = `hello: "Hello, {{.name}}!"
+apple:
+  description: Number of apples
+  one: "{{.PluralCount}} apple"
+  other: "{{.PluralCount}} apples"
+terms: 'Read the <a href="{{.url}}">terms</a>'
+brand: Acme
+welcome: Welcome to $t(brand), {{.name}}
+`
+
+const idYAML = `# Reviewed by the Indonesian team.
+brand: Acme
+hello: "Halo {{.name}}"
+terms: 'Baca <a href="{{.url}}">ketentuan</a>'
+welcome: "Selamat datang di $t(brand), {{.name}}" # keep the shorthand reference
+`
+
+const idJSON = `{
+    "hello": "Halo {{.name}}",
+    "welcome": "Selamat datang di $t(brand)"
+}
+`
+
+func TestFill(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		args     []string
+		out      string
+		expected string
+		output   string
+	}{
+		{
+			name:  "new language",
+			files: map[string]string{"en.yaml": enYAML},
+			args:  []string{"fill", "--to=ja", "{dir}/en.yaml"},
+			out:   "ja.yaml",
+			expected: `apple:
+    description: 'needs-review: Number of apples'
+    one: '[ja] {{.PluralCount}} apple'
+    other: '[ja] {{.PluralCount}} apples'
+brand:
+    description: needs-review
+    other: '[ja] Acme'
+hello:
+    description: needs-review
+    other: '[ja] Hello, {{.name}}!'
+terms:
+    description: needs-review
+    other: '[ja] Read the <a href="{{.url}}">terms</a>'
+welcome:
+    description: needs-review
+    other: '[ja] Welcome to $t(brand), {{.name}}'
+`,
+			output: "filled 5 messages in {dir}/ja.yaml\n",
+		},
+		{
+			name:  "existing language",
+			files: map[string]string{"en.yaml": enYAML, "id.yaml": idYAML},
+			args:  []string{"fill", "--to=id", "{dir}/en.yaml", "{dir}/id.yaml"},
+			out:   "id.yaml",
+			expected: idYAML + `apple:
+    description: 'needs-review: Number of apples'
+    one: '[id] {{.PluralCount}} apple'
+    other: '[id] {{.PluralCount}} apples'
+`,
+			output: "filled 1 messages in {dir}/id.yaml\n",
+		},
+		{
+			name:  "existing json file",
+			files: map[string]string{"en.yaml": enYAML, "id.json": idJSON},
+			args:  []string{"fill", "--to=id", "{dir}/en.yaml", "{dir}/id.json"},
+			out:   "id.json",
+			expected: `{
+    "hello": "Halo {{.name}}",
+    "welcome": "Selamat datang di $t(brand)",
+  "apple": {
+    "description": "needs-review: Number of apples",
+    "one": "[id] {{.PluralCount}} apple",
+    "other": "[id] {{.PluralCount}} apples"
+  },
+  "brand": {
+    "description": "needs-review",
+    "other": "[id] Acme"
+  },
+  "terms": {
+    "description": "needs-review",
+    "other": "[id] Read the <a href=\"{{.url}}\">terms</a>"
+  }
+}
+`,
+			output: "filled 3 messages in {dir}/id.json\n",
+		},
+		{
+			name:  "json output",
+			files: map[string]string{"en.yaml": `hello: "Hello"` + "\n"},
+			args:  []string{"fill", "--to=ja", "--out={dir}/ja.json", "{dir}/en.yaml"},
+			out:   "ja.json",
+			expected: `{
+  "hello": {
+    "description": "needs-review",
+    "other": "[ja] Hello"
+  }
+}
+`,
+			output: "filled 1 messages in {dir}/ja.json\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			}
+			args := make([]string, len(tc.args))
+			for i, arg := range tc.args {
+				args[i] = strings.ReplaceAll(arg, "{dir}", dir)
+			}
+
+			var stdout bytes.Buffer
+			require.NoError(t, run(context.Background(), args, &stdout))
+			assert.Equal(t, strings.ReplaceAll(tc.output, "{dir}", dir), stdout.String())
+
+			buf, err := os.ReadFile(filepath.Join(dir, tc.out))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(buf))
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name   string
+		files  map[string]string
+		args   []string
+		err    string
+		output string
+	}{
+		{
+			name:   "no problems",
+			files:  map[string]string{"en.yaml": enYAML, "id.yaml": idYAML},
+			args:   []string{"check", "{dir}/en.yaml", "{dir}/id.yaml"},
+			output: "no problems found\n",
+		},
+		{
+			name: "problems",
+			files: map[string]string{
+				"en.yaml":   enYAML + "footer: See $t(help)\n",
+				"id.yaml":   idYAML,
+				"info.yaml": "terms:\n  maxLength: 10\n",
+			},
+			args: []string{"check", "--info={dir}/info.yaml", "{dir}/en.yaml", "{dir}/id.yaml"},
+			err:  "found 3 problems",
+			output: `footer (en, other): references the missing message help
+terms (en, other): 14 characters exceed the max length of 10
+terms (id, other): 14 characters exceed the max length of 10
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			}
+			args := make([]string, len(tc.args))
+			for i, arg := range tc.args {
+				args[i] = strings.ReplaceAll(arg, "{dir}", dir)
+			}
+
+			var stdout bytes.Buffer
+			err := run(context.Background(), args, &stdout)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.output, stdout.String())
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "no command",
+			expected: usage,
+		},
+		{
+			name:     "missing language",
+			args:     []string{"fill", "en.yaml"},
+			expected: usage,
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"lint", "en.yaml"},
+			expected: usage,
+		},
+		{
+			name:     "check without files",
+			args:     []string{"check"},
+			expected: usage,
+		},
+		{
+			name:     "unknown provider",
+			args:     []string{"fill", "--to=ja", "--provider=deepl", "en.yaml"},
+			expected: `unknown provider "deepl"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, run(context.Background(), tc.args, &bytes.Buffer{}), tc.expected)
+		})
+	}
+}
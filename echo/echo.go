@@ -0,0 +1,47 @@
+// Package i18necho provides the i18n middleware and helpers for Echo.
+//
+// Example:
+//
+//	e := echo.New()
+//	e.Use(i18necho.Middleware())
+//	e.GET("/hello", func(c echo.Context) error {
+//		return c.String(http.StatusOK, i18necho.T(c, "hello"))
+//	})
+package i18necho
+
+import (
+	"github.com/ahmadfaizk/i18n"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/text/language"
+)
+
+// Middleware returns a middleware that sets the language to the request context from the Accept-Language header.
+//
+// The language is stored in the request context, so i18n.GetCtx(c.Request().Context(), ...) finds it.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			lang := req.Header.Get("Accept-Language")
+			if lang != "" {
+				ctx := i18n.NewContextWithLanguage(req.Context(), lang)
+				c.SetRequest(req.WithContext(ctx))
+			}
+			return next(c)
+		}
+	}
+}
+
+// T returns the translated message for the given message id in the language of the request.
+//
+// Example:
+//
+//	message := i18necho.T(c, "hello", i18n.Param("name", "John"))
+func T(c echo.Context, id string, opts ...any) string {
+	return i18n.GetCtx(c.Request().Context(), id, opts...)
+}
+
+// GetLanguage returns the language tag of the request.
+func GetLanguage(c echo.Context) language.Tag {
+	return i18n.GetLanguage(c.Request().Context())
+}
@@ -0,0 +1,85 @@
+package i18necho_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	i18necho "github.com/ahmadfaizk/i18n/echo"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMiddleware(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("../testdata/en.yaml", "../testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(i18necho.Middleware())
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, i18necho.T(c, "test"))
+	})
+	e.GET("/hello", func(c echo.Context) error {
+		return c.String(http.StatusOK, i18necho.T(c, "hello", i18n.Param("name", c.QueryParam("name"))))
+	})
+	e.GET("/lang", func(c echo.Context) error {
+		return c.String(http.StatusOK, i18necho.GetLanguage(c).String())
+	})
+
+	testCases := []struct {
+		name            string
+		lang            string
+		url             string
+		expectedMessage string
+	}{
+		{
+			name:            "without header",
+			url:             "/",
+			expectedMessage: "This is test message",
+		},
+		{
+			name:            "with accept-language id",
+			url:             "/",
+			lang:            "id",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+		{
+			name:            "with accept-language id and param",
+			url:             "/hello?name=John",
+			lang:            "id",
+			expectedMessage: "Halo John",
+		},
+		{
+			name:            "with multiple accept-language",
+			url:             "/",
+			lang:            "es-ES,id-ID,en-US",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+		{
+			name:            "language",
+			url:             "/lang",
+			lang:            "id-ID,en-US",
+			expectedMessage: "id-ID",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.lang != "" {
+				req.Header.Set("Accept-Language", tc.lang)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.expectedMessage, rec.Body.String())
+		})
+	}
+}
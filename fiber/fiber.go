@@ -0,0 +1,47 @@
+// Package i18nfiber provides the i18n middleware and helpers for Fiber.
+//
+// Fiber is built on fasthttp, so the language is stored in the user context and in the user values of the request.
+//
+// Example:
+//
+//	app := fiber.New()
+//	app.Use(i18nfiber.Middleware())
+//	app.Get("/hello", func(c *fiber.Ctx) error {
+//		return c.SendString(i18nfiber.T(c, "hello"))
+//	})
+package i18nfiber
+
+import (
+	"github.com/ahmadfaizk/i18n"
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/text/language"
+)
+
+// Middleware returns a middleware that sets the language to the user context from the Accept-Language header.
+//
+// The language is stored in the user context and in the user values of the request, so both
+// i18n.GetCtx(c.UserContext(), ...) and i18n.GetCtx(c.Context(), ...) find it.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lang := c.Get(fiber.HeaderAcceptLanguage)
+		if lang != "" {
+			c.SetUserContext(i18n.NewContextWithLanguage(c.UserContext(), lang))
+			c.Context().SetUserValue(i18n.LanguageContextKey, lang)
+		}
+		return c.Next()
+	}
+}
+
+// T returns the translated message for the given message id in the language of the request.
+//
+// Example:
+//
+//	message := i18nfiber.T(c, "hello", i18n.Param("name", "John"))
+func T(c *fiber.Ctx, id string, opts ...any) string {
+	return i18n.GetCtx(c.UserContext(), id, opts...)
+}
+
+// GetLanguage returns the language tag of the request.
+func GetLanguage(c *fiber.Ctx) language.Tag {
+	return i18n.GetLanguage(c.UserContext())
+}
@@ -0,0 +1,98 @@
+package i18nfiber_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	i18nfiber "github.com/ahmadfaizk/i18n/fiber"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMiddleware(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("../testdata/en.yaml", "../testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(i18nfiber.Middleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(i18nfiber.T(c, "test"))
+	})
+	app.Get("/hello", func(c *fiber.Ctx) error {
+		return c.SendString(i18nfiber.T(c, "hello", i18n.Param("name", c.Query("name"))))
+	})
+	app.Get("/fasthttp", func(c *fiber.Ctx) error {
+		return c.SendString(i18n.GetCtx(c.Context(), "test"))
+	})
+	app.Get("/lang", func(c *fiber.Ctx) error {
+		return c.SendString(i18nfiber.GetLanguage(c).String())
+	})
+
+	testCases := []struct {
+		name            string
+		lang            string
+		url             string
+		expectedMessage string
+	}{
+		{
+			name:            "without header",
+			url:             "/",
+			expectedMessage: "This is test message",
+		},
+		{
+			name:            "with accept-language id",
+			url:             "/",
+			lang:            "id",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+		{
+			name:            "with accept-language id and param",
+			url:             "/hello?name=John",
+			lang:            "id",
+			expectedMessage: "Halo John",
+		},
+		{
+			name:            "with multiple accept-language",
+			url:             "/",
+			lang:            "es-ES,id-ID,en-US",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+		{
+			name:            "with accept-language id in fasthttp context",
+			url:             "/fasthttp",
+			lang:            "id",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+		{
+			name:            "language",
+			url:             "/lang",
+			lang:            "id-ID,en-US",
+			expectedMessage: "id-ID",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.lang != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tc.lang)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tc.expectedMessage, string(body))
+		})
+	}
+}
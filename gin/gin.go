@@ -0,0 +1,44 @@
+// Package i18ngin provides the i18n middleware and helpers for Gin.
+//
+// Example:
+//
+//	r := gin.New()
+//	r.Use(i18ngin.Middleware())
+//	r.GET("/hello", func(c *gin.Context) {
+//		c.String(http.StatusOK, i18ngin.T(c, "hello"))
+//	})
+package i18ngin
+
+import (
+	"github.com/ahmadfaizk/i18n"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// Middleware returns a middleware that sets the language to the request context from the Accept-Language header.
+//
+// The language is stored in the request context, so i18n.GetCtx(c.Request.Context(), ...) finds it.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := c.GetHeader("Accept-Language")
+		if lang != "" {
+			ctx := i18n.NewContextWithLanguage(c.Request.Context(), lang)
+			c.Request = c.Request.WithContext(ctx)
+		}
+		c.Next()
+	}
+}
+
+// T returns the translated message for the given message id in the language of the request.
+//
+// Example:
+//
+//	message := i18ngin.T(c, "hello", i18n.Param("name", "John"))
+func T(c *gin.Context, id string, opts ...any) string {
+	return i18n.GetCtx(c.Request.Context(), id, opts...)
+}
+
+// GetLanguage returns the language tag of the request.
+func GetLanguage(c *gin.Context) language.Tag {
+	return i18n.GetLanguage(c.Request.Context())
+}
@@ -0,0 +1,23 @@
+module github.com/ahmadfaizk/i18n/grpc
+
+go 1.25.0
+
+require (
+	github.com/ahmadfaizk/i18n v0.1.0
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/text v0.40.0
+	google.golang.org/grpc v1.84.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/nicksnyder/go-i18n/v2 v2.4.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
+
+replace github.com/ahmadfaizk/i18n => ../
@@ -0,0 +1,88 @@
+// Package i18ngrpc provides gRPC interceptors that propagate the language between services.
+//
+// The server interceptors read the accept-language metadata into the context, so i18n.GetCtx finds it.
+// The client interceptors forward the language of the context as the accept-language metadata.
+//
+// Example:
+//
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(i18ngrpc.UnaryServerInterceptor()),
+//		grpc.ChainStreamInterceptor(i18ngrpc.StreamServerInterceptor()),
+//	)
+//
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainUnaryInterceptor(i18ngrpc.UnaryClientInterceptor()),
+//		grpc.WithChainStreamInterceptor(i18ngrpc.StreamClientInterceptor()),
+//	)
+package i18ngrpc
+
+import (
+	"context"
+
+	"github.com/ahmadfaizk/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the metadata key that carries the language.
+const MetadataKey = "accept-language"
+
+func incomingContext(ctx context.Context) context.Context {
+	values := metadata.ValueFromIncomingContext(ctx, MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx
+	}
+	return i18n.NewContextWithLanguage(ctx, values[0])
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	lang := i18n.ExtractLanguage(ctx)
+	if lang == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, lang)
+}
+
+// UnaryServerInterceptor returns a server interceptor that sets the language from the metadata to the context.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incomingContext(ctx), req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor that sets the language from the metadata to the stream context.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incomingContext(ss.Context())})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor returns a client interceptor that forwards the language of the context in the metadata.
+//
+// The language set explicitly in the outgoing metadata is kept.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor that forwards the language of the context in the metadata.
+//
+// The language set explicitly in the outgoing metadata is kept.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
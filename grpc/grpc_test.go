@@ -0,0 +1,165 @@
+package i18ngrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	i18ngrpc "github.com/ahmadfaizk/i18n/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"gopkg.in/yaml.v3"
+)
+
+// recorder records the message translated with the context seen by the service.
+type recorder struct {
+	messages chan string
+}
+
+func (r *recorder) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	r.messages <- i18n.TCtx(ctx, "test")
+	return handler(ctx, req)
+}
+
+func (r *recorder) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	r.messages <- i18n.TCtx(ss.Context(), "test")
+	return nil
+}
+
+func newClient(t *testing.T, rec *recorder, opts ...grpc.DialOption) healthpb.HealthClient {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(i18ngrpc.UnaryServerInterceptor(), rec.unary),
+		grpc.ChainStreamInterceptor(i18ngrpc.StreamServerInterceptor(), rec.stream),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	opts = append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func initI18n(t *testing.T) {
+	t.Helper()
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("../testdata/en.yaml", "../testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+}
+
+func TestServerInterceptor(t *testing.T) {
+	initI18n(t)
+	rec := &recorder{messages: make(chan string, 1)}
+	client := newClient(t, rec)
+
+	testCases := []struct {
+		name            string
+		lang            string
+		expectedMessage string
+	}{
+		{
+			name:            "without metadata",
+			expectedMessage: "This is test message",
+		},
+		{
+			name:            "with accept-language id",
+			lang:            "id",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+		{
+			name:            "with multiple accept-language",
+			lang:            "es-ES,id-ID,en-US",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.lang != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, i18ngrpc.MetadataKey, tc.lang)
+			}
+
+			_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMessage, <-rec.messages)
+
+			stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			_, _ = stream.Recv()
+			assert.Equal(t, tc.expectedMessage, <-rec.messages)
+		})
+	}
+}
+
+func TestClientInterceptor(t *testing.T) {
+	initI18n(t)
+	rec := &recorder{messages: make(chan string, 1)}
+	client := newClient(t, rec,
+		grpc.WithChainUnaryInterceptor(i18ngrpc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(i18ngrpc.StreamClientInterceptor()),
+	)
+
+	testCases := []struct {
+		name            string
+		lang            string
+		metadata        string
+		expectedMessage string
+	}{
+		{
+			name:            "without language",
+			expectedMessage: "This is test message",
+		},
+		{
+			name:            "with context language",
+			lang:            "id",
+			expectedMessage: "Ini adalah pesan tes",
+		},
+		{
+			name:            "with explicit metadata",
+			lang:            "id",
+			metadata:        "en",
+			expectedMessage: "This is test message",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.lang != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.lang)
+			}
+			if tc.metadata != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, i18ngrpc.MetadataKey, tc.metadata)
+			}
+
+			_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMessage, <-rec.messages)
+
+			stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			_, _ = stream.Recv()
+			assert.Equal(t, tc.expectedMessage, <-rec.messages)
+		})
+	}
+}
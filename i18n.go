@@ -3,16 +3,32 @@ package i18n
 import (
 	"context"
 	"errors"
+	"sync"
+	texttemplate "text/template"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
 )
 
 var (
+	mu                        sync.RWMutex
 	bundle                    *i18n.Bundle
+	messageCatalog            *catalog
+	messageConflicts          []Conflict
+	messageInfos              map[string]*MessageMetadata
+	referencingMessages       map[string]bool
+	rawMessages               bool
+	escapeHTMLParams          bool
+	pseudoBundle              *i18n.Bundle
+	sources                   []Source
+	tenants                   map[string]*i18n.Bundle
 	defaultLanguage           language.Tag
 	missingTranslationHandler func(string, error) string
 	extractLanguageFunc       func(context.Context) string
+	extractTenantFunc         func(context.Context) string
+	bidiIsolation             bool
+	statsRecorder             *recorder
+	hooks                     []Hook
 
 	ErrI18nNotInitialized = errors.New("i18n is not initialized")
 )
@@ -43,37 +59,114 @@ func Init(language language.Tag, opts ...Option) error {
 	defaultOpts := []Option{
 		WithMissingTranslationHandler(defaultMissingTranslationFunc),
 		WithExtractLanguageFunc(defaultExtractLanguageFunc),
+		WithExtractTenantFunc(defaultExtractTenantFunc),
 	}
 	opts = append(defaultOpts, opts...)
 	config := newI18nConfig(opts...)
 
-	defaultLanguage = language
-	missingTranslationHandler = config.missingTranslationHandler
-	extractLanguageFunc = config.extractLanguageFunc
-
-	bundle = i18n.NewBundle(language)
+	b := i18n.NewBundle(language)
 	for format, unmarshalFunc := range config.unmarshalFuncMap {
-		bundle.RegisterUnmarshalFunc(format, unmarshalFunc)
+		b.RegisterUnmarshalFunc(format, unmarshalFunc)
+	}
+
+	files, err := readTranslationFiles(config)
+	if err != nil {
+		return err
+	}
+	files = append(files, config.messageFiles...)
+	sourceFiles, err := loadSources(context.WithValue(context.Background(), fullLoadCtxKey, true), config.sources)
+	if err != nil {
+		return err
 	}
+	files = append(files, sourceFiles...)
 
-	for _, path := range config.translationFiles {
-		_, err := bundle.LoadMessageFile(path)
-		if err != nil {
+	files, conflicts, err := mergeMessageFiles(files, config.mergePolicy)
+	if err != nil {
+		return err
+	}
+	c := newCatalog()
+	c.leftDelim, c.rightDelim = config.leftDelim, config.rightDelim
+	if _, err := addMessageFiles(b, c, files); err != nil {
+		return err
+	}
+	if err := c.checkReferenceCycles(language); err != nil {
+		return err
+	}
+	infos, err := readMessageInfoFiles(config)
+	if err != nil {
+		return err
+	}
+	var pseudo *i18n.Bundle
+	if config.pseudoLocalization {
+		pseudo = i18n.NewBundle(PseudoAccented)
+		if err := addPseudoMessages(pseudo, c.preparedMessages(language), c.leftDelim, c.rightDelim); err != nil {
 			return err
 		}
 	}
-	for _, translationFSFile := range config.translationFSFiles {
-		for _, path := range translationFSFile.paths {
-			_, err := bundle.LoadMessageFileFS(translationFSFile.fs, path)
-			if err != nil {
-				return err
-			}
-		}
+	references := c.referencingMessages()
+	tenantBundles, err := loadTenantBundles(language, config, references)
+	if err != nil {
+		return err
+	}
+	if err := syncTenantTags(b, tenantBundles); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	bundle = b
+	messageCatalog = c
+	messageConflicts = conflicts
+	messageInfos = infos
+	referencingMessages = references
+	rawMessages = config.rawMessages
+	escapeHTMLParams = config.escapeHTML
+	pseudoBundle = pseudo
+	sources = config.sources
+	tenants = tenantBundles
+	defaultLanguage = language
+	missingTranslationHandler = config.missingTranslationHandler
+	extractLanguageFunc = config.extractLanguageFunc
+	extractTenantFunc = config.extractTenantFunc
+	bidiIsolation = config.bidiIsolation
+	hooks = config.hooks
+	statsRecorder = nil
+	if config.stats {
+		statsRecorder = newRecorder()
 	}
 
 	return nil
 }
 
+// Reset clears the state set by Init, so the package is not initialized until Init is called again.
+//
+// It is useful to isolate the tests that call Init.
+//
+// Example:
+//
+//	t.Cleanup(i18n.Reset)
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	bundle = nil
+	messageCatalog = nil
+	messageConflicts = nil
+	messageInfos = nil
+	referencingMessages = nil
+	rawMessages = false
+	escapeHTMLParams = false
+	pseudoBundle = nil
+	sources = nil
+	tenants = nil
+	defaultLanguage = language.Und
+	missingTranslationHandler = nil
+	extractLanguageFunc = nil
+	extractTenantFunc = nil
+	bidiIsolation = false
+	hooks = nil
+	statsRecorder = nil
+}
+
 // Get returns the translated message for the given message id.
 //
 // It uses the default language tag.
@@ -89,40 +182,153 @@ func Get(id string, opts ...any) string {
 //
 // It uses the language from the context. You can set the language to the context with i18n.Middleware.
 // If the language is not found in the context, it uses the default language tag.
+// If the context has a tenant with its own messages, they override the messages of the bundle.
 //
 // Example:
 //
 //	message := i18n.GetCtx(ctx, "hello", i18n.Params{"name": "John"})
 func GetCtx(ctx context.Context, id string, opts ...any) string {
-	if bundle == nil {
+	state := currentLocalizeState()
+	if state.bundle == nil {
 		panic(ErrI18nNotInitialized)
 	}
+	return localize(ctx, state, id, 0, opts...)
+}
+
+// localizeState is the state of the package used to localize a message and the messages it references.
+type localizeState struct {
+	bundle          *i18n.Bundle
+	defaultLanguage language.Tag
+	extractLanguage func(context.Context) string
+	extractTenant   func(context.Context) string
+	handleMissing   func(string, error) string
+	tenants         map[string]*i18n.Bundle
+	pseudo          *i18n.Bundle
+	references      map[string]bool
+	leftDelim       string
+	rightDelim      string
+	raw             bool
+	escape          bool
+	isolate         bool
+	stats           *recorder
+	hooks           []Hook
+}
+
+func currentLocalizeState() *localizeState {
+	mu.RLock()
+	defer mu.RUnlock()
+	if bundle == nil {
+		return &localizeState{}
+	}
+	return &localizeState{
+		bundle:          bundle,
+		defaultLanguage: defaultLanguage,
+		extractLanguage: extractLanguageFunc,
+		extractTenant:   extractTenantFunc,
+		handleMissing:   missingTranslationHandler,
+		tenants:         tenants,
+		pseudo:          pseudoBundle,
+		references:      referencingMessages,
+		leftDelim:       messageCatalog.leftDelim,
+		rightDelim:      messageCatalog.rightDelim,
+		raw:             rawMessages,
+		escape:          escapeHTMLParams,
+		isolate:         bidiIsolation,
+		stats:           statsRecorder,
+		hooks:           hooks,
+	}
+}
 
+// localize returns the translated message for GetCtx with the state of the package when GetCtx is called.
+//
+// The read lock is held only while the bundle renders the message, because Refresh adds messages to the bundle.
+// The messages it references, the hooks and the missing translation handler run without the lock.
+// The depth is the nesting of the references.
+func localize(ctx context.Context, state *localizeState, id string, depth int, opts ...any) string {
 	cfg := newLocalizeConfig(opts...)
+	if (state.escape && !cfg.noEscapeHTML) || cfg.escapeHTML {
+		escapeParams(cfg.params)
+	}
+	if state.isolate || cfg.isolate {
+		isolateParams(cfg.params)
+	}
 	localizeConfig := cfg.toI18nLocalizeConfig(id)
+	var (
+		funcs      texttemplate.FuncMap
+		references []reference
+	)
+	if state.references[id] || cfg.leftDelim != "" || cfg.rightDelim != "" {
+		funcs = referenceFuncs(cfg, depth, &references)
+	}
+	leftDelim, rightDelim := state.leftDelim, state.rightDelim
+	perCall := cfg.leftDelim != "" || cfg.rightDelim != ""
+	if perCall {
+		leftDelim, rightDelim = cfg.leftDelim, cfg.rightDelim
+	}
+	localizeConfig.TemplateParser = templateParser(leftDelim, rightDelim, perCall, state.raw || cfg.raw, funcs)
 
-	var languages []string
-	if cfg.language != "" {
-		languages = append(languages, cfg.language)
+	languages := requestedLanguages(ctx, cfg.language, state.extractLanguage, state.defaultLanguage)
+
+	localizer := i18n.NewLocalizer(state.bundle, languages...)
+	var tenantLocalizer *i18n.Localizer
+	if pseudoLocalizer := newPseudoLocalizer(state.pseudo, languages[0]); pseudoLocalizer != nil {
+		localizer = pseudoLocalizer
+	} else if tenantBundle, ok := state.tenants[state.extractTenant(ctx)]; ok {
+		tenantLocalizer = i18n.NewLocalizer(tenantBundle, languages...)
 	}
-	lang := extractLanguageFunc(ctx)
-	if lang != "" && !contains(languages, lang) {
-		languages = append(languages, lang)
+
+	referenceCtx := ctx
+	for _, hook := range state.hooks {
+		ctx = hook.BeforeLocalize(ctx, id, languages)
 	}
-	if !contains(languages, defaultLanguage.String()) {
-		languages = append(languages, defaultLanguage.String())
+
+	mu.RLock()
+	var (
+		message string
+		tag     language.Tag
+		err     error
+	)
+	if tenantLocalizer != nil {
+		message, tag, err = localizeTenant(tenantLocalizer, localizer, localizeConfig)
+	} else {
+		message, tag, err = localizer.LocalizeWithTag(localizeConfig)
 	}
+	mu.RUnlock()
+	message = renderReferences(referenceCtx, state, message, references, depth)
 
-	localizer := i18n.NewLocalizer(bundle, languages...)
-	message, err := localizer.Localize(localizeConfig)
+	if state.stats != nil || len(state.hooks) > 0 {
+		result := newLocalizeResult(id, languages, message, tag, err)
+		if state.stats != nil {
+			state.stats.record(result)
+		}
+		for i := len(state.hooks) - 1; i >= 0; i-- {
+			state.hooks[i].AfterLocalize(ctx, result)
+		}
+	}
 
 	if message == "" {
-		return missingTranslationHandler(id, err)
+		return state.handleMissing(id, err)
 	}
 
 	return message
 }
 
+// requestedLanguages returns the languages in order of preference: the language of the option,
+// the language of the context and the default language.
+func requestedLanguages(ctx context.Context, lang string, extract func(context.Context) string, fallback language.Tag) []string {
+	var languages []string
+	if lang != "" {
+		languages = append(languages, lang)
+	}
+	if lang := extract(ctx); lang != "" && !contains(languages, lang) {
+		languages = append(languages, lang)
+	}
+	if !contains(languages, fallback.String()) {
+		languages = append(languages, fallback.String())
+	}
+	return languages
+}
+
 // T is an alias for Get.
 //
 // Example:
@@ -16,6 +16,22 @@ type config struct {
 	unmarshalFuncMap          map[string]i18n.UnmarshalFunc
 	translationFiles          []string
 	translationFSFiles        []translationFSFile
+	messageFiles              []*i18n.MessageFile
+	messageInfoFiles          []string
+	messageInfoFSFiles        []messageInfoFSFile
+	sources                   []Source
+	tenantTranslations        []tenantTranslation
+	tenantDirs                []string
+	extractTenantFunc         func(ctx context.Context) string
+	pseudoLocalization        bool
+	bidiIsolation             bool
+	stats                     bool
+	hooks                     []Hook
+	mergePolicy               MergePolicy
+	leftDelim                 string
+	rightDelim                string
+	rawMessages               bool
+	escapeHTML                bool
 	extractLanguageFunc       func(ctx context.Context) string
 	missingTranslationHandler func(id string, err error) string
 }
@@ -58,6 +74,58 @@ func WithTranslationFSFile(fs embed.FS, paths ...string) Option {
 	}
 }
 
+// WithMessageFile adds already parsed message files to the bundle.
+//
+// It is useful when the messages come from a format that is not supported by the unmarshal functions,
+// e.g. the files parsed by the mobile package.
+func WithMessageFile(files ...*i18n.MessageFile) Option {
+	return func(c *config) {
+		c.messageFiles = append(c.messageFiles, files...)
+	}
+}
+
+// WithSource adds sources of message files to the bundle.
+//
+// The sources are loaded by Init after the translation files and reloaded by Refresh.
+func WithSource(sources ...Source) Option {
+	return func(c *config) {
+		c.sources = append(c.sources, sources...)
+	}
+}
+
+// WithDelimiters sets the delimiters of the template actions for the messages without their own delimiters.
+//
+// It is useful for catalogs that contain literal {{ and }}, e.g. Mustache emails. The $t(id) references
+// are expanded with these delimiters, or with the Delims of the call.
+//
+// Example:
+//
+//	i18n.Init(language.English, i18n.WithDelimiters("[[", "]]"))
+func WithDelimiters(left, right string) Option {
+	return func(c *config) {
+		c.leftDelim = left
+		c.rightDelim = right
+	}
+}
+
+// WithRawMessages returns the messages as is without executing them as templates.
+//
+// The params, plural count and message references are not rendered.
+func WithRawMessages() Option {
+	return func(c *config) {
+		c.rawMessages = true
+	}
+}
+
+// WithHTMLEscape escapes the string values of the params for HTML, unless they are html/template.HTML.
+//
+// It is useful when the messages are written to HTML without html/template.
+func WithHTMLEscape() Option {
+	return func(c *config) {
+		c.escapeHTML = true
+	}
+}
+
 // WithMissingTranslationHandler sets the missing translation handler for the bundle.
 //
 // It is used to handle the missing translation. The default handler returns the message ID.
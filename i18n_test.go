@@ -65,6 +65,24 @@ func TestI18n(t *testing.T) {
 			options:         []any{i18n.Lang("id")},
 			expectedMessage: "This message is only available in English.",
 		},
+		{
+			name:            "with plural one",
+			messageID:       "apple",
+			options:         []any{i18n.Plural(1)},
+			expectedMessage: "1 apple",
+		},
+		{
+			name:            "with plural other",
+			messageID:       "apple",
+			options:         []any{i18n.Plural(3)},
+			expectedMessage: "3 apples",
+		},
+		{
+			name:            "with plural and custom language",
+			messageID:       "apple",
+			options:         []any{i18n.Lang("id"), i18n.Plural(1)},
+			expectedMessage: "1 apel",
+		},
 		{
 			name:            "not found",
 			messageID:       "not_found",
@@ -156,3 +174,17 @@ func TestI18nWhenTranslationNotFound(t *testing.T) {
 	err = i18n.Init(language.English, i18n.WithTranslationFSFile(testdata.FS, "es.yaml"))
 	assert.Error(t, err)
 }
+
+func TestReset(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFSFile(testdata.FS, "en.yaml"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", i18n.T("hello_world"))
+
+	i18n.Reset()
+	assert.Panics(t, func() {
+		i18n.T("hello_world")
+	})
+}
@@ -0,0 +1,194 @@
+// Package i18ntest initializes the i18n package with in-memory catalogs for tests.
+//
+// NewTranslator calls i18n.Init with the messages of the test and resets the package when the test ends,
+// so the messages of a test do not leak into the next one. The translator records every message rendered
+// during the test, which the assertion helpers use.
+//
+// The i18n package keeps its state in package variables, so the tests that use a Translator must not run in parallel.
+//
+// Example:
+//
+//	func TestWelcome(t *testing.T) {
+//		tr := i18ntest.NewTranslator(t, map[string]map[string]string{
+//			"en": {"welcome": "Welcome, {{.name}}!"},
+//			"id": {"welcome": "Selamat datang, {{.name}}!"},
+//		})
+//
+//		ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+//		tr.AssertTranslated(ctx, "welcome", "Selamat datang, John!", i18n.Param("name", "John"))
+//		tr.AssertNoMissing()
+//	}
+package i18ntest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+type config struct {
+	defaultLanguage language.Tag
+	initOptions     []i18n.Option
+}
+
+// Option configures NewTranslator.
+type Option func(*config)
+
+// WithDefaultLanguage sets the default language of the translator. It defaults to English.
+func WithDefaultLanguage(tag language.Tag) Option {
+	return func(c *config) {
+		c.defaultLanguage = tag
+	}
+}
+
+// WithInitOptions adds options to the i18n.Init call, e.g. i18n.WithMissingTranslationHandler.
+func WithInitOptions(opts ...i18n.Option) Option {
+	return func(c *config) {
+		c.initOptions = append(c.initOptions, opts...)
+	}
+}
+
+// Translator is the i18n package initialized for a test. It records the messages rendered during the test.
+type Translator struct {
+	t       testing.TB
+	mu      sync.Mutex
+	results []i18n.LocalizeResult
+}
+
+// NewTranslator initializes the i18n package with the messages, which map the languages to the message ids
+// and their templates. The package is reset when the test ends.
+//
+// Example:
+//
+//	tr := i18ntest.NewTranslator(t, map[string]map[string]string{
+//		"en": {"cart": "Cart"},
+//		"id": {"cart": "Keranjang"},
+//	}, i18ntest.WithDefaultLanguage(language.Indonesian))
+func NewTranslator(t testing.TB, messages map[string]map[string]string, opts ...Option) *Translator {
+	t.Helper()
+
+	cfg := &config{defaultLanguage: language.English}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	files, err := messageFiles(messages)
+	if err != nil {
+		t.Fatalf("i18ntest: %v", err)
+	}
+
+	tr := &Translator{t: t}
+	initOpts := append([]i18n.Option{i18n.WithMessageFile(files...), i18n.WithHook(tr)}, cfg.initOptions...)
+	if err := i18n.Init(cfg.defaultLanguage, initOpts...); err != nil {
+		t.Fatalf("i18ntest: %v", err)
+	}
+	t.Cleanup(i18n.Reset)
+	return tr
+}
+
+// BeforeLocalize implements i18n.Hook.
+func (tr *Translator) BeforeLocalize(ctx context.Context, _ string, _ []string) context.Context {
+	return ctx
+}
+
+// AfterLocalize implements i18n.Hook.
+func (tr *Translator) AfterLocalize(_ context.Context, result i18n.LocalizeResult) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.results = append(tr.results, result)
+}
+
+// Results returns the results of the messages rendered during the test in order.
+func (tr *Translator) Results() []i18n.LocalizeResult {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return append([]i18n.LocalizeResult(nil), tr.results...)
+}
+
+// Rendered returns the ids of the messages rendered during the test in order of their first rendering.
+func (tr *Translator) Rendered() []string {
+	return tr.ids(func(i18n.LocalizeResult) bool { return true })
+}
+
+// Missing returns the ids of the messages that were rendered during the test but are not found in any language.
+func (tr *Translator) Missing() []string {
+	return tr.ids(func(result i18n.LocalizeResult) bool { return result.Missing })
+}
+
+func (tr *Translator) ids(filter func(i18n.LocalizeResult) bool) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, result := range tr.Results() {
+		if filter(result) && !seen[result.ID] {
+			seen[result.ID] = true
+			ids = append(ids, result.ID)
+		}
+	}
+	return ids
+}
+
+// AssertTranslated asserts that the message is rendered as expected in the language of the context
+// and that it is not missing.
+func (tr *Translator) AssertTranslated(ctx context.Context, id, expected string, opts ...any) bool {
+	tr.t.Helper()
+
+	before := len(tr.Results())
+	actual := i18n.TCtx(ctx, id, opts...)
+	for _, result := range tr.Results()[before:] {
+		if result.ID == id && result.Missing {
+			tr.t.Errorf("i18ntest: message %q is missing", id)
+			return false
+		}
+	}
+	if actual != expected {
+		tr.t.Errorf("i18ntest: message %q\nexpected: %q\nactual  : %q", id, expected, actual)
+		return false
+	}
+	return true
+}
+
+// AssertNoMissing asserts that every message rendered during the test so far is found.
+func (tr *Translator) AssertNoMissing() bool {
+	tr.t.Helper()
+
+	if missing := tr.Missing(); len(missing) > 0 {
+		tr.t.Errorf("i18ntest: missing messages: %q", missing)
+		return false
+	}
+	return true
+}
+
+// messageFiles converts the messages to a message file per language, sorted by language and id.
+func messageFiles(messages map[string]map[string]string) ([]*goi18n.MessageFile, error) {
+	langs := make([]string, 0, len(messages))
+	for lang := range messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	files := make([]*goi18n.MessageFile, 0, len(langs))
+	for _, lang := range langs {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language %q: %w", lang, err)
+		}
+		ids := make([]string, 0, len(messages[lang]))
+		for id := range messages[lang] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		file := &goi18n.MessageFile{Path: "i18ntest/" + lang, Tag: tag}
+		for _, id := range ids {
+			file.Messages = append(file.Messages, &goi18n.Message{ID: id, Other: messages[lang][id]})
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
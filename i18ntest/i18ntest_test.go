@@ -0,0 +1,67 @@
+package i18ntest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/ahmadfaizk/i18n/i18ntest"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// recordingT records the errors of the assertions instead of failing the test.
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+var messages = map[string]map[string]string{
+	"en": {"cart": "Cart", "hello": "Hello, {{.name}}!"},
+	"id": {"cart": "Keranjang"},
+}
+
+func TestNewTranslator(t *testing.T) {
+	t.Run("translate", func(t *testing.T) {
+		tr := i18ntest.NewTranslator(t, messages)
+
+		ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+		assert.True(t, tr.AssertTranslated(ctx, "cart", "Keranjang"))
+		assert.True(t, tr.AssertTranslated(ctx, "hello", "Hello, John!", i18n.Param("name", "John")))
+		assert.True(t, tr.AssertNoMissing())
+		assert.Equal(t, []string{"cart", "hello"}, tr.Rendered())
+	})
+
+	t.Run("default language", func(t *testing.T) {
+		i18ntest.NewTranslator(t, messages, i18ntest.WithDefaultLanguage(language.Indonesian))
+
+		assert.Equal(t, "Keranjang", i18n.T("cart"))
+	})
+
+	t.Run("reset after the test", func(t *testing.T) {
+		assert.Panics(t, func() {
+			i18n.T("cart")
+		})
+	})
+}
+
+func TestTranslatorAssertions(t *testing.T) {
+	rt := &recordingT{TB: t}
+	tr := i18ntest.NewTranslator(rt, messages)
+
+	ctx := context.Background()
+	assert.False(t, tr.AssertTranslated(ctx, "cart", "Basket"))
+	assert.False(t, tr.AssertTranslated(ctx, "checkout", "checkout"))
+	assert.False(t, tr.AssertNoMissing())
+	assert.Equal(t, []string{"checkout"}, tr.Missing())
+	assert.Equal(t, []string{
+		"i18ntest: message \"cart\"\nexpected: \"Basket\"\nactual  : \"Cart\"",
+		"i18ntest: message \"checkout\" is missing",
+		"i18ntest: missing messages: [\"checkout\"]",
+	}, rt.errors)
+}
@@ -0,0 +1,247 @@
+package i18n
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// CatalogFormat is the JSON format of the exported catalog.
+type CatalogFormat string
+
+const (
+	// CatalogI18next is the i18next JSON v4 format. The keys are flat, so i18next must be configured with
+	// keySeparator: false. The plural forms are exported with the _zero, _one, _two, _few, _many and _other suffixes.
+	CatalogI18next CatalogFormat = "i18next"
+	// CatalogFormatJS is the FormatJS simple format, which maps the ids to ICU messages.
+	CatalogFormatJS CatalogFormat = "formatjs"
+)
+
+// ExportCatalog serializes the messages of the language whose id starts with the prefix to JSON in the format.
+//
+// The {{.name}} placeholders are converted to {{name}} for i18next and {name} for FormatJS, and PluralCount
+// becomes the count variable. The message references are converted to $t(id) for i18next.
+// Other template actions are exported as is for i18next and as text for FormatJS.
+//
+// Example:
+//
+//	buf, err := i18n.ExportCatalog(language.Indonesian, i18n.CatalogI18next, "checkout.")
+func ExportCatalog(tag language.Tag, format CatalogFormat, prefix string) ([]byte, error) {
+	var messages []Message
+	for _, message := range Messages(tag) {
+		if strings.HasPrefix(message.ID, prefix) {
+			messages = append(messages, message)
+		}
+	}
+	leftDelim, rightDelim := catalogDelims()
+	for i := range messages {
+		m := messages[i].withDelims(leftDelim, rightDelim)
+		for _, text := range []*string{&m.Zero, &m.One, &m.Two, &m.Few, &m.Many, &m.Other} {
+			*text = expandShorthandReferences(*text, m.LeftDelim, m.RightDelim)
+		}
+		messages[i] = m
+	}
+
+	catalog := make(map[string]string, len(messages))
+	switch format {
+	case CatalogI18next:
+		for _, message := range messages {
+			if !message.isPlural() {
+				catalog[message.ID] = i18nextText(message, message.Other)
+				continue
+			}
+			for _, form := range message.forms() {
+				if form.text != "" {
+					catalog[message.ID+"_"+form.name] = i18nextText(message, form.text)
+				}
+			}
+		}
+	case CatalogFormatJS:
+		for _, message := range messages {
+			catalog[message.ID] = icuMessage(message)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported catalog format %q", format)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(catalog); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CatalogHandler returns a handler that serves the catalog in the format with an ETag.
+//
+// The language is taken from the lang query parameter, the language of the request context and the Accept-Language
+// header, and matched with the languages of the bundle. The prefix query parameter filters the ids.
+//
+// Example:
+//
+//	http.Handle("/locales.json", i18n.CatalogHandler(i18n.CatalogI18next))
+//
+//	// GET /locales.json?lang=id&prefix=checkout.
+func CatalogHandler(format CatalogFormat) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.RLock()
+		b, fallback := bundle, defaultLanguage
+		mu.RUnlock()
+		if b == nil {
+			panic(ErrI18nNotInitialized)
+		}
+
+		var languages []string
+		for _, lang := range []string{r.URL.Query().Get("lang"), ExtractLanguage(r.Context()), r.Header.Get("Accept-Language")} {
+			if lang != "" {
+				languages = append(languages, lang)
+			}
+		}
+		tag := matchLanguage(b, append(languages, fallback.String()))
+
+		buf, err := ExportCatalog(tag, format, r.URL.Query().Get("prefix"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sum := sha256.Sum256(buf)
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Content-Language", tag.String())
+		w.Header().Set("Vary", "Accept-Language")
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(buf)
+	})
+}
+
+func (m Message) isPlural() bool {
+	return m.Zero != "" || m.One != "" || m.Two != "" || m.Few != "" || m.Many != ""
+}
+
+func (m Message) forms() []messageForm {
+	return []messageForm{
+		{name: "zero", text: m.Zero},
+		{name: "one", text: m.One},
+		{name: "two", text: m.Two},
+		{name: "few", text: m.Few},
+		{name: "many", text: m.Many},
+		{name: "other", text: m.Other},
+	}
+}
+
+var defaultPlaceholderRegexp = placeholderRegexp("", "")
+
+// placeholderRegexp matches the {{.name}} placeholders of the template with the delimiters.
+func placeholderRegexp(leftDelim, rightDelim string) *regexp.Regexp {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(leftDelim) + `-?\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*-?` + regexp.QuoteMeta(rightDelim))
+}
+
+func placeholderName(name string) string {
+	if name == "PluralCount" {
+		return "count"
+	}
+	return name
+}
+
+func i18nextText(m Message, text string) string {
+	pattern := defaultReferenceRegexp
+	if m.LeftDelim != "" || m.RightDelim != "" {
+		pattern = referenceRegexp(m.LeftDelim, m.RightDelim)
+	}
+	// The message references use the nesting syntax of i18next.
+	text = pattern.ReplaceAllString(text, "$$t($1)")
+	return convertPlaceholders(m, text, nil, func(name string) string {
+		return "{{" + placeholderName(name) + "}}"
+	})
+}
+
+func icuMessage(m Message) string {
+	if !m.isPlural() {
+		return icuText(m, m.Other, false)
+	}
+	var buf strings.Builder
+	buf.WriteString("{count, plural,")
+	for _, form := range m.forms() {
+		if form.text != "" {
+			buf.WriteString(" " + form.name + " {" + icuText(m, form.text, true) + "}")
+		}
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// icuText converts the placeholders to ICU arguments and quotes the ICU syntax characters of the text.
+func icuText(m Message, text string, plural bool) string {
+	escape := func(s string) string {
+		return icuEscape(s, plural)
+	}
+	return convertPlaceholders(m, text, escape, func(name string) string {
+		if plural && name == "PluralCount" {
+			return "#"
+		}
+		return "{" + placeholderName(name) + "}"
+	})
+}
+
+// icuEscape doubles the apostrophes and quotes the runs of ICU syntax characters, e.g. {{ becomes '{{'.
+// A run is quoted as a whole because an apostrophe pair inside a quoted run is a literal apostrophe.
+func icuEscape(text string, plural bool) string {
+	var buf strings.Builder
+	quoted := false
+	for _, r := range text {
+		special := r == '{' || r == '}' || (plural && r == '#')
+		if special != quoted {
+			buf.WriteByte('\'')
+			quoted = special
+		}
+		if r == '\'' {
+			buf.WriteString("''")
+			continue
+		}
+		buf.WriteRune(r)
+	}
+	if quoted {
+		buf.WriteByte('\'')
+	}
+	return buf.String()
+}
+
+// convertPlaceholders replaces the placeholders of the text and escapes the rest of the text.
+func convertPlaceholders(m Message, text string, escape func(string) string, placeholder func(name string) string) string {
+	if escape == nil {
+		escape = func(s string) string { return s }
+	}
+	pattern := defaultPlaceholderRegexp
+	if m.LeftDelim != "" || m.RightDelim != "" {
+		pattern = placeholderRegexp(m.LeftDelim, m.RightDelim)
+	}
+	var buf strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		buf.WriteString(escape(text[last:match[0]]))
+		buf.WriteString(placeholder(text[match[2]:match[3]]))
+		last = match[1]
+	}
+	buf.WriteString(escape(text[last:]))
+	return buf.String()
+}
@@ -0,0 +1,115 @@
+package i18n_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func initCatalogExport(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithMessageFile(&goi18n.MessageFile{
+			Path: "export.yaml",
+			Tag:  language.English,
+			Messages: []*goi18n.Message{
+				{ID: "export.quote", Other: "It's {{ .name }}'s {cart} #1"},
+				{ID: "export.delims", LeftDelim: "<<", RightDelim: ">>", Other: "Hi <<.name>>, {{.name}}"},
+				{ID: "export.ref", Other: "$t(hello_world)!"},
+				{ID: "export.items", One: "{{.PluralCount}} item in #{{.cart}}", Other: "{{.PluralCount}} items"},
+			},
+		}),
+	)
+	require.NoError(t, err)
+}
+
+func TestExportCatalog(t *testing.T) {
+	initCatalogExport(t)
+
+	testCases := []struct {
+		name     string
+		language language.Tag
+		format   i18n.CatalogFormat
+		prefix   string
+		expected string
+	}{
+		{
+			name:     "i18next",
+			language: language.English,
+			format:   i18n.CatalogI18next,
+			prefix:   "export.",
+			expected: `{"export.delims":"Hi {{name}}, {{.name}}","export.items_one":"{{count}} item in #{{cart}}","export.items_other":"{{count}} items","export.quote":"It's {{name}}'s {cart} #1","export.ref":"$t(hello_world)!"}`,
+		},
+		{
+			name:     "i18next language",
+			language: language.Indonesian,
+			format:   i18n.CatalogI18next,
+			prefix:   "h",
+			expected: `{"hello":"Halo {{name}}","hello_age":"Halo {{name}}! Kamu berumur {{age}} tahun.","hello_world":"Halo, Dunia!"}`,
+		},
+		{
+			name:     "formatjs",
+			language: language.English,
+			format:   i18n.CatalogFormatJS,
+			prefix:   "export.",
+			expected: `{"export.delims":"Hi {name}, '{{'.name'}}'","export.items":"{count, plural, one {# item in '#'{cart}} other {# items}}","export.quote":"It''s {name}''s '{'cart'}' #1","export.ref":"'{{'t \"hello_world\"'}}'!"}`,
+		},
+		{
+			name:     "formatjs markup",
+			language: language.Indonesian,
+			format:   i18n.CatalogFormatJS,
+			prefix:   "terms",
+			expected: `{"terms":"Baca <a href=\"{url}\">ketentuan</a>, {name}"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf, err := i18n.ExportCatalog(tc.language, tc.format, tc.prefix)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected+"\n", string(buf))
+		})
+	}
+
+	_, err := i18n.ExportCatalog(language.English, "po", "")
+	assert.EqualError(t, err, `unsupported catalog format "po"`)
+}
+
+func TestCatalogHandler(t *testing.T) {
+	initCatalogExport(t)
+	handler := i18n.CatalogHandler(i18n.CatalogI18next)
+
+	req := httptest.NewRequest(http.MethodGet, "/locales.json?prefix=hello_w", nil)
+	req.Header.Set("Accept-Language", "id-ID,id;q=0.9")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "id", rec.Header().Get("Content-Language"))
+	assert.Equal(t, `{"hello_world":"Halo, Dunia!"}`+"\n", rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	req = httptest.NewRequest(http.MethodGet, "/locales.json?prefix=hello_w&lang=en", nil)
+	req.Header.Set("Accept-Language", "id")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, `{"hello_world":"Hello, World!"}`+"\n", rec.Body.String())
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	req = httptest.NewRequest(http.MethodGet, "/locales.json?prefix=hello_w", nil)
+	req = req.WithContext(i18n.NewContextWithLanguage(req.Context(), "id"))
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
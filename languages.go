@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// LanguageOption describes a language of the bundle for a language switcher.
+type LanguageOption struct {
+	// Tag is the language tag, e.g. id.
+	Tag language.Tag
+	// Autonym is the name of the language in itself, e.g. Indonesia.
+	Autonym string
+	// Name is the name of the language in the language of the context, e.g. Indonesian.
+	Name string
+	// Completeness is the percentage of the messages of the default language that are translated to the language.
+	Completeness float64
+	// Selected reports whether the language is the one used for the context.
+	Selected bool
+}
+
+// Languages returns the languages of the bundle. The default language comes first.
+//
+// Example:
+//
+//	for _, tag := range i18n.Languages() {
+//		fmt.Println(tag)
+//	}
+func Languages() []language.Tag {
+	mu.RLock()
+	defer mu.RUnlock()
+	if bundle == nil {
+		panic(ErrI18nNotInitialized)
+	}
+	return bundle.LanguageTags()
+}
+
+// LanguageOptions returns the languages of the bundle with their names and completeness for a language switcher.
+//
+// The names are given in the language itself and in the language of the context.
+// The completeness is computed against the messages of the default language.
+//
+// Example:
+//
+//	for _, option := range i18n.LanguageOptions(r.Context()) {
+//		fmt.Printf("%s (%s) %.0f%%\n", option.Autonym, option.Name, option.Completeness)
+//	}
+func LanguageOptions(ctx context.Context) []LanguageOption {
+	current := resolveLanguage(ctx)
+	namer := display.Tags(current)
+
+	mu.RLock()
+	defer mu.RUnlock()
+	tags := bundle.LanguageTags()
+	defaults := messageCatalog.entries[defaultLanguage]
+
+	options := make([]LanguageOption, 0, len(tags))
+	for _, tag := range tags {
+		completeness := 100.0
+		if len(defaults) > 0 {
+			translated := 0
+			for id := range defaults {
+				if _, ok := messageCatalog.entries[tag][id]; ok {
+					translated++
+				}
+			}
+			completeness = float64(translated) * 100 / float64(len(defaults))
+		}
+		options = append(options, LanguageOption{
+			Tag:          tag,
+			Autonym:      languageName(display.Self, tag),
+			Name:         languageName(namer, tag),
+			Completeness: completeness,
+			Selected:     tag == current,
+		})
+	}
+	return options
+}
+
+func languageName(namer display.Namer, tag language.Tag) string {
+	if name := namer.Name(tag); name != "" {
+		return name
+	}
+	return tag.String()
+}
@@ -0,0 +1,64 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLanguages(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml", "testdata/ar.yaml"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []language.Tag{language.English, language.Indonesian, language.Arabic}, i18n.Languages())
+}
+
+func TestLanguageOptions(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml", "testdata/ar.yaml"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		language string
+		expected []i18n.LanguageOption
+	}{
+		{
+			name: "default language",
+			expected: []i18n.LanguageOption{
+				{Tag: language.English, Autonym: "English", Name: "English", Completeness: 100, Selected: true},
+				{Tag: language.Indonesian, Autonym: "Indonesia", Name: "Indonesian", Completeness: 800.0 / 9},
+				{Tag: language.Arabic, Autonym: "العربية", Name: "Arabic", Completeness: 200.0 / 9},
+			},
+		},
+		{
+			name:     "request language",
+			language: "id",
+			expected: []i18n.LanguageOption{
+				{Tag: language.English, Autonym: "English", Name: "Inggris", Completeness: 100},
+				{Tag: language.Indonesian, Autonym: "Indonesia", Name: "Indonesia", Completeness: 800.0 / 9, Selected: true},
+				{Tag: language.Arabic, Autonym: "العربية", Name: "Arab", Completeness: 200.0 / 9},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.language != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.language)
+			}
+			assert.Equal(t, tc.expected, i18n.LanguageOptions(ctx))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// Hook observes the localization of the messages by GetCtx, e.g. for tracing.
+//
+// The hooks are called for every message, so they must be fast and safe for concurrent use.
+type Hook interface {
+	// BeforeLocalize is called before the message is localized with the requested languages in order of preference.
+	// The returned context is passed to AfterLocalize.
+	BeforeLocalize(ctx context.Context, id string, languages []string) context.Context
+	// AfterLocalize is called after the message is localized.
+	AfterLocalize(ctx context.Context, result LocalizeResult)
+}
+
+// LocalizeResult is the result of the localization of a message.
+type LocalizeResult struct {
+	// ID is the message id.
+	ID string
+	// Languages are the requested languages in order of preference.
+	Languages []string
+	// Language is the language of the served message. When the message is missing,
+	// it is the language of the bundle that matches the requested languages.
+	Language language.Tag
+	// Fallback reports whether the message is served in another language than the first requested language.
+	Fallback bool
+	// Missing reports whether the message is not found in any language.
+	Missing bool
+	// Err is the error of the localization.
+	Err error
+}
+
+// WithHook adds hooks that are called around the localization of every message.
+//
+// Example:
+//
+//	i18n.Init(language.English, i18n.WithHook(i18notel.NewHook()))
+func WithHook(hooks ...Hook) Option {
+	return func(c *config) {
+		c.hooks = append(c.hooks, hooks...)
+	}
+}
+
+func newLocalizeResult(id string, languages []string, message string, tag language.Tag, err error) LocalizeResult {
+	result := LocalizeResult{ID: id, Languages: languages, Language: tag, Missing: message == "", Err: err}
+	if result.Missing {
+		var notFound *i18n.MessageNotFoundErr
+		if errors.As(err, &notFound) {
+			result.Language = notFound.Tag
+		}
+		return result
+	}
+	if requested, ok := firstRequestedLanguage(languages); ok {
+		_, _, confidence := language.NewMatcher([]language.Tag{tag}).Match(requested)
+		result.Fallback = confidence < language.High
+	}
+	return result
+}
+
+// firstRequestedLanguage returns the first language of the most preferred requested languages,
+// which may be an Accept-Language header.
+func firstRequestedLanguage(languages []string) (language.Tag, bool) {
+	if len(languages) == 0 {
+		return language.Und, false
+	}
+	tags, _, err := language.ParseAcceptLanguage(languages[0])
+	if err != nil || len(tags) == 0 {
+		return language.Und, false
+	}
+	return tags[0], true
+}
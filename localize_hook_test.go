@@ -0,0 +1,110 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+type hookCtxKey struct{}
+
+type recordingHook struct {
+	name   string
+	calls  *[]string
+	result i18n.LocalizeResult
+}
+
+func (h *recordingHook) BeforeLocalize(ctx context.Context, id string, languages []string) context.Context {
+	*h.calls = append(*h.calls, "before "+h.name+" "+id)
+	return context.WithValue(ctx, hookCtxKey{}, h.name)
+}
+
+func (h *recordingHook) AfterLocalize(ctx context.Context, result i18n.LocalizeResult) {
+	*h.calls = append(*h.calls, "after "+h.name+" "+ctx.Value(hookCtxKey{}).(string))
+	h.result = result
+}
+
+func TestHook(t *testing.T) {
+	var calls []string
+	first := &recordingHook{name: "first", calls: &calls}
+	second := &recordingHook{name: "second", calls: &calls}
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithHook(first, second),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		language        string
+		messageID       string
+		expectedMessage string
+		expectedResult  i18n.LocalizeResult
+		expectedErr     bool
+	}{
+		{
+			name:            "hit",
+			language:        "id",
+			messageID:       "hello_world",
+			expectedMessage: "Halo, Dunia!",
+			expectedResult:  i18n.LocalizeResult{ID: "hello_world", Languages: []string{"id", "en"}, Language: language.Indonesian},
+		},
+		{
+			name:            "fallback",
+			language:        "id",
+			messageID:       "only_in_en",
+			expectedMessage: "This message is only available in English.",
+			expectedResult: i18n.LocalizeResult{
+				ID: "only_in_en", Languages: []string{"id", "en"}, Language: language.English, Fallback: true,
+			},
+			expectedErr: true,
+		},
+		{
+			name:            "unloaded language",
+			language:        "fr",
+			messageID:       "hello_world",
+			expectedMessage: "Hello, World!",
+			expectedResult: i18n.LocalizeResult{
+				ID: "hello_world", Languages: []string{"fr", "en"}, Language: language.English, Fallback: true,
+			},
+		},
+		{
+			name:            "missing",
+			messageID:       "not_found",
+			expectedMessage: "not_found",
+			expectedResult: i18n.LocalizeResult{
+				ID: "not_found", Languages: []string{"en"}, Language: language.English, Missing: true,
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+			ctx := context.Background()
+			if tc.language != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.language)
+			}
+			assert.Equal(t, tc.expectedMessage, i18n.TCtx(ctx, tc.messageID))
+			assert.Equal(t, []string{
+				"before first " + tc.messageID,
+				"before second " + tc.messageID,
+				"after second second",
+				"after first second",
+			}, calls)
+			result := second.result
+			if tc.expectedErr {
+				assert.Error(t, result.Err)
+			}
+			result.Err = nil
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package i18n
 
 import (
-	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"fmt"
+	htmltemplate "html/template"
 	"reflect"
+	texttemplate "text/template"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/nicksnyder/go-i18n/v2/i18n/template"
 )
 
 // Params is an alias for map[string]interface{}. It is used to set template data for the message.
@@ -16,6 +21,14 @@ type localizeConfig struct {
 	params         map[string]interface{}
 	defaultMessage string
 	language       string
+	pluralCount    interface{}
+	isolate        bool
+	leftDelim      string
+	rightDelim     string
+	raw            bool
+	escapeHTML     bool
+	// noEscapeHTML skips WithHTMLEscape for the template functions, whose output is escaped by the template.
+	noEscapeHTML bool
 }
 
 func newLocalizeConfig(opts ...any) *localizeConfig {
@@ -39,6 +52,7 @@ func (c localizeConfig) toI18nLocalizeConfig(id string) *i18n.LocalizeConfig {
 	localizeConfig := &i18n.LocalizeConfig{
 		MessageID:    id,
 		TemplateData: c.params,
+		PluralCount:  c.pluralCount,
 	}
 	if c.defaultMessage != "" {
 		localizeConfig.DefaultMessage = &i18n.Message{
@@ -90,3 +104,117 @@ func Default(defaultMessage string) LocalizeOption {
 		c.defaultMessage = defaultMessage
 	}
 }
+
+// Plural sets the plural count for the message.
+//
+// It selects the plural form of the message and sets the PluralCount template data.
+//
+// Example:
+//
+//	i18n.T("apple", i18n.Plural(2))
+func Plural(count interface{}) LocalizeOption {
+	return func(c *localizeConfig) {
+		c.pluralCount = count
+		c.params["PluralCount"] = count
+	}
+}
+
+// Delims sets the delimiters of the template actions for the message.
+//
+// They take precedence over WithDelimiters, but the delimiters of the message in the catalog take precedence over them.
+//
+// Example:
+//
+//	i18n.T("email.body", i18n.Delims("[[", "]]"), i18n.Param("name", "John"))
+func Delims(left, right string) LocalizeOption {
+	return func(c *localizeConfig) {
+		c.leftDelim = left
+		c.rightDelim = right
+	}
+}
+
+// Raw returns the message as is without executing it as a template.
+//
+// It is useful for messages that contain literal template syntax, e.g. Mustache templates.
+//
+// Example:
+//
+//	i18n.T("email.mustache", i18n.Raw())
+func Raw() LocalizeOption {
+	return func(c *localizeConfig) {
+		c.raw = true
+	}
+}
+
+// EscapeHTML escapes the string values of the params for HTML, unless they are html/template.HTML.
+//
+// Example:
+//
+//	i18n.T("hello", i18n.Param("name", "<b>John</b>"), i18n.EscapeHTML())
+func EscapeHTML() LocalizeOption {
+	return func(c *localizeConfig) {
+		c.escapeHTML = true
+	}
+}
+
+// escapeParams escapes the string values of the params for HTML. The escaped values are html/template.HTML,
+// so they are not escaped twice. The other values, e.g. numbers, are kept as they are, so they can still be
+// compared in the templates.
+// withoutHTMLEscape skips the escaping of the params by WithHTMLEscape.
+func withoutHTMLEscape() LocalizeOption {
+	return func(c *localizeConfig) {
+		c.noEscapeHTML = true
+	}
+}
+
+func escapeParams(params map[string]interface{}) {
+	for key, value := range params {
+		params[key] = escapeValue(value)
+	}
+}
+
+func escapeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case htmltemplate.HTML:
+		return v
+	case string:
+		return htmltemplate.HTML(htmltemplate.HTMLEscapeString(v))
+	case fmt.Stringer:
+		return htmltemplate.HTML(htmltemplate.HTMLEscapeString(v.String()))
+	}
+	return value
+}
+
+// textParser expands the $t(id) references of the template with the delimiters it is parsed with.
+//
+// The templates parsed with the per call delimiters are not cacheable, because the parsed templates of
+// the messages are cached regardless of the parser.
+type textParser struct {
+	*template.TextParser
+	perCall bool
+}
+
+func (p textParser) Cacheable() bool {
+	return !p.perCall && p.TextParser.Cacheable()
+}
+
+func (p textParser) Parse(src, leftDelim, rightDelim string) (template.ParsedTemplate, error) {
+	if leftDelim == "" && rightDelim == "" {
+		leftDelim, rightDelim = p.LeftDelim, p.RightDelim
+	}
+	return p.TextParser.Parse(expandShorthandReferences(src, leftDelim, rightDelim), leftDelim, rightDelim)
+}
+
+// templateParser returns the parser of the message, or nil for the default parser.
+//
+// The delimiters are the defaults for the messages without their own delimiters. The templates parsed with
+// the delimiters of the bundle are cached, those parsed with the per call delimiters are not.
+func templateParser(leftDelim, rightDelim string, perCall, raw bool, funcs texttemplate.FuncMap) template.Parser {
+	if raw {
+		return template.IdentityParser{}
+	}
+	if leftDelim == "" && rightDelim == "" && funcs == nil {
+		return nil
+	}
+	return textParser{TextParser: &template.TextParser{LeftDelim: leftDelim, RightDelim: rightDelim, Funcs: funcs}, perCall: perCall}
+}
@@ -0,0 +1,130 @@
+package i18n_test
+
+import (
+	htmltemplate "html/template"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTemplateOptions(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+		i18n.WithMessageFile(&goi18n.MessageFile{
+			Path: "email.yaml",
+			Tag:  language.English,
+			Messages: []*goi18n.Message{
+				{ID: "email.mustache", Other: "Hi <%.name%>, {{user.name}}"},
+				{ID: "email.delims", LeftDelim: "[[", RightDelim: "]]", Other: "Hi [[.name]], $t(cart) {{user.name}}"},
+				{ID: "email.count", Other: "{{.name}} has {{if gt .n 1}}{{.n}} emails{{else}}one email{{end}}"},
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		messageID       string
+		options         []any
+		expectedMessage string
+	}{
+		{
+			name:            "default delimiters",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", "John")},
+			expectedMessage: "Hello, John!",
+		},
+		{
+			name:            "per call delimiters",
+			messageID:       "email.mustache",
+			options:         []any{i18n.Param("name", "John"), i18n.Delims("<%", "%>")},
+			expectedMessage: "Hi John, {{user.name}}",
+		},
+		{
+			name:            "per call delimiters do not change the cached template",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", "John"), i18n.Delims("<%", "%>")},
+			expectedMessage: "Hello, {{.name}}!",
+		},
+		{
+			name:            "message delimiters",
+			messageID:       "email.delims",
+			options:         []any{i18n.Param("name", "John")},
+			expectedMessage: "Hi John, Cart {{user.name}}",
+		},
+		{
+			name:            "raw",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", "John"), i18n.Raw()},
+			expectedMessage: "Hello, {{.name}}!",
+		},
+		{
+			name:            "escape html",
+			messageID:       "hello_age",
+			options:         []any{i18n.Params{"name": "<b>John</b>", "age": htmltemplate.HTML("<i>30</i>")}, i18n.EscapeHTML()},
+			expectedMessage: "Hello, &lt;b&gt;John&lt;/b&gt;! You are <i>30</i> years old.",
+		},
+		{
+			name:            "escape html keeps numbers",
+			messageID:       "email.count",
+			options:         []any{i18n.Params{"name": "<b>John</b>", "n": 3}, i18n.EscapeHTML()},
+			expectedMessage: "&lt;b&gt;John&lt;/b&gt; has 3 emails",
+		},
+		{
+			name:            "no escape by default",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", "<b>John</b>")},
+			expectedMessage: "Hello, <b>John</b>!",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMessage, i18n.T(tc.messageID, tc.options...))
+		})
+	}
+	assert.Equal(t, "Hello, John!", i18n.T("hello", i18n.Param("name", "John")))
+}
+
+func TestGlobalTemplateOptions(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithMessageFile(&goi18n.MessageFile{
+			Path: "email.yaml",
+			Tag:  language.English,
+			Messages: []*goi18n.Message{
+				{ID: "brand", Other: "Acme"},
+				{ID: "email.subject", Other: "{{#user}}[[.name]] from $t(brand){{/user}}"},
+				{ID: "email.erb", Other: "Hi <%.name%>, [[.name]]"},
+				{ID: "email.plain", Other: "Hi {{.name}} from $t(brand)"},
+			},
+		}),
+		i18n.WithDelimiters("[[", "]]"),
+		i18n.WithHTMLEscape(),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "{{#user}}&lt;John&gt; from Acme{{/user}}", i18n.T("email.subject", i18n.Param("name", "<John>")))
+	assert.Equal(t, "Hi John, [[.name]]", i18n.T("email.erb", i18n.Param("name", "John"), i18n.Delims("<%", "%>")))
+	assert.Equal(t, "Hi <%.name%>, John", i18n.T("email.erb", i18n.Param("name", "John")))
+	assert.Equal(t, "Hi John from Acme", i18n.T("email.plain", i18n.Param("name", "John"), i18n.Delims("{{", "}}")))
+	assert.Equal(t, "Hi {{.name}} from Acme", i18n.T("email.plain", i18n.Param("name", "John")))
+	message, ok := i18n.Lookup("email.subject", language.English)
+	require.True(t, ok)
+	assert.Equal(t, "{{#user}}[[.name]] from $t(brand){{/user}}", message.Other)
+	assert.Empty(t, message.LeftDelim)
+
+	err = i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+		i18n.WithRawMessages(),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello, {{.name}}!", i18n.T("hello", i18n.Param("name", "John")))
+}
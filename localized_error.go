@@ -0,0 +1,151 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// Error is an error with a message ID that is translated to the language of the request.
+//
+// Example:
+//
+//	var ErrUserNotFound = i18n.NewError("error.user_not_found", nil).WithStatus(http.StatusNotFound)
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		if err := findUser(r.Context()); err != nil {
+//			i18n.WriteError(w, r, err)
+//			return
+//		}
+//	}
+type Error struct {
+	// ID is the message ID of the error.
+	ID string
+	// Params is the template data of the message.
+	Params Params
+	// Status is the HTTP status code written by WriteError. The default is 500.
+	Status int
+	// Err is the wrapped cause of the error.
+	Err error
+}
+
+// NewError returns an error with the given message ID and params.
+func NewError(id string, params Params) *Error {
+	return &Error{ID: id, Params: params}
+}
+
+// WrapError returns an error with the given message ID and params that wraps the cause.
+func WrapError(err error, id string, params Params) *Error {
+	return &Error{ID: id, Params: params, Err: err}
+}
+
+// WithStatus returns a copy of the error with the HTTP status code.
+func (e *Error) WithStatus(status int) *Error {
+	c := *e
+	c.Status = status
+	return &c
+}
+
+// Error returns the message in the default language followed by the cause.
+func (e *Error) Error() string {
+	message := defaultLanguageMessage(e.ID, e.Params)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// defaultLanguageMessage returns the message in the default language or the message ID if i18n is not initialized.
+func defaultLanguageMessage(id string, params Params) string {
+	mu.RLock()
+	initialized := bundle != nil
+	mu.RUnlock()
+	if !initialized {
+		return id
+	}
+	return Get(id, params)
+}
+
+// Unwrap returns the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Localize returns the message in the language of the context without the cause.
+func (e *Error) Localize(ctx context.Context) string {
+	return GetCtx(ctx, e.ID, e.Params)
+}
+
+// AsError finds the first *Error in the chain of err.
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// LocalizeError returns the message of the first *Error in the chain of err in the language of the context.
+//
+// If there is no *Error in the chain, it returns err.Error().
+func LocalizeError(ctx context.Context, err error) string {
+	if e, ok := AsError(err); ok {
+		return e.Localize(ctx)
+	}
+	return err.Error()
+}
+
+// Problem is the problem details body written by WriteError as defined in RFC 9457.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Code   string `json:"code,omitempty"`
+}
+
+// statusTitlePrefix is the prefix of the message IDs of the problem titles, e.g. http.status.404.
+const statusTitlePrefix = "http.status."
+
+// WriteError writes the error as a problem details JSON body in the language of the request.
+//
+// The detail is the localized message of the first *Error in the chain of err and the code is its message ID.
+// Other errors are written as 500 Internal Server Error without detail, so internal messages are not leaked.
+// The title is the message http.status.<code>, e.g. http.status.404, or the status text of net/http when
+// the catalog does not have it. The Content-Language header is the language of the request matched with the bundle.
+//
+// Example:
+//
+//	# locales/id.yaml
+//	http.status.404: "Tidak Ditemukan"
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := Problem{
+		Type:   "about:blank",
+		Status: http.StatusInternalServerError,
+	}
+	if e, ok := AsError(err); ok {
+		if e.Status != 0 {
+			problem.Status = e.Status
+		}
+		problem.Detail = e.Localize(r.Context())
+		problem.Code = e.ID
+	}
+	problem.Title = http.StatusText(problem.Status)
+
+	mu.RLock()
+	initialized := bundle != nil
+	mu.RUnlock()
+	if initialized {
+		ctx := r.Context()
+		if id := statusTitlePrefix + strconv.Itoa(problem.Status); Has(ctx, id) {
+			problem.Title = GetCtx(ctx, id)
+		}
+		w.Header().Set("Content-Language", resolveLanguage(ctx).String())
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
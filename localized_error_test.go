@@ -0,0 +1,123 @@
+package i18n_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestError(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	errNotFound := i18n.WrapError(sql.ErrNoRows, "user_not_found", i18n.Params{"name": "John"}).WithStatus(http.StatusNotFound)
+	wrapped := fmt.Errorf("get user: %w", errNotFound)
+	ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+
+	assert.Equal(t, "User John not found: sql: no rows in result set", errNotFound.Error())
+	assert.Equal(t, "Pengguna John tidak ditemukan", errNotFound.Localize(ctx))
+	assert.ErrorIs(t, wrapped, sql.ErrNoRows)
+
+	e, ok := i18n.AsError(wrapped)
+	require.True(t, ok)
+	assert.Equal(t, "user_not_found", e.ID)
+	assert.Equal(t, http.StatusNotFound, e.Status)
+
+	assert.Equal(t, "Pengguna John tidak ditemukan", i18n.LocalizeError(ctx, wrapped))
+	assert.Equal(t, "plain error", i18n.LocalizeError(ctx, errors.New("plain error")))
+
+	_, ok = i18n.AsError(errors.New("plain error"))
+	assert.False(t, ok)
+}
+
+func TestWriteError(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithMessageFile(&goi18n.MessageFile{
+			Tag:      language.Indonesian,
+			Messages: []*goi18n.Message{{ID: "http.status.404", Other: "Tidak Ditemukan"}},
+		}),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		lang            string
+		err             error
+		expected        i18n.Problem
+		contentLanguage string
+	}{
+		{
+			name: "localized error",
+			lang: "id",
+			err:  fmt.Errorf("handler: %w", i18n.NewError("user_not_found", i18n.Params{"name": "John"}).WithStatus(http.StatusNotFound)),
+			expected: i18n.Problem{
+				Type:   "about:blank",
+				Title:  "Tidak Ditemukan",
+				Status: http.StatusNotFound,
+				Detail: "Pengguna John tidak ditemukan",
+				Code:   "user_not_found",
+			},
+			contentLanguage: "id",
+		},
+		{
+			name: "localized error without status",
+			err:  i18n.NewError("user_not_found", i18n.Params{"name": "John"}),
+			expected: i18n.Problem{
+				Type:   "about:blank",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+				Detail: "User John not found",
+				Code:   "user_not_found",
+			},
+			contentLanguage: "en",
+		},
+		{
+			name: "plain error",
+			err:  errors.New("connection refused"),
+			expected: i18n.Problem{
+				Type:   "about:blank",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+			contentLanguage: "en",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.lang != "" {
+				req.Header.Set("Accept-Language", tc.lang)
+			}
+			rec := httptest.NewRecorder()
+
+			i18n.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i18n.WriteError(w, r, tc.err)
+			})).ServeHTTP(rec, req)
+
+			var problem i18n.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tc.expected.Status, rec.Code)
+			assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+			assert.Equal(t, tc.contentLanguage, rec.Header().Get("Content-Language"))
+			assert.Equal(t, tc.expected, problem)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/text/language"
+)
+
+// NeedsReview marks the description of the messages filled by a machine translation provider,
+// so the translators can find the drafts.
+const NeedsReview = "needs-review"
+
+// Translator is a machine translation provider, e.g. a client of a translation API.
+//
+// Translate returns the translations of the texts in the same order. The template actions, HTML tags and
+// entities of the texts are replaced with <x id="0"/> tags, which the provider must keep.
+type Translator interface {
+	Translate(ctx context.Context, from, to language.Tag, texts []string) ([]string, error)
+}
+
+// TranslatorFunc is an adapter to allow the use of ordinary functions as Translator.
+type TranslatorFunc func(ctx context.Context, from, to language.Tag, texts []string) ([]string, error)
+
+// Translate calls f(ctx, from, to, texts).
+func (f TranslatorFunc) Translate(ctx context.Context, from, to language.Tag, texts []string) ([]string, error) {
+	return f(ctx, from, to, texts)
+}
+
+// FakeTranslator is a deterministic Translator for offline tests. It prefixes the texts with the target language,
+// e.g. "Hello" becomes "[ja] Hello".
+type FakeTranslator struct{}
+
+// Translate returns the texts prefixed with the target language.
+func (FakeTranslator) Translate(_ context.Context, _, to language.Tag, texts []string) ([]string, error) {
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translations[i] = "[" + to.String() + "] " + text
+	}
+	return translations, nil
+}
+
+var protectedTagRegexp = regexp.MustCompile(`<x id="(\d+)"/>`)
+
+var translationProtected = translationProtectedRegexp("", "")
+
+// translationProtectedRegexp matches the $t(id) references, template actions, HTML tags and entities
+// that are not sent to the translator.
+func translationProtectedRegexp(leftDelim, rightDelim string) *regexp.Regexp {
+	return regexp.MustCompile(shorthandReferenceRegexp.String() + "|" + pseudoProtectedRegexp(leftDelim, rightDelim).String())
+}
+
+// Fill translates the messages of the default language that are missing in the language with the translator.
+//
+// The template actions and markup of the messages are protected from the translator. The returned messages
+// are sorted by id and their descriptions start with NeedsReview.
+//
+// Example:
+//
+//	drafts, err := i18n.Fill(ctx, provider, language.Japanese)
+//	if err != nil {
+//		return err
+//	}
+//	for _, message := range drafts {
+//		fmt.Println(message.ID, message.Other)
+//	}
+func Fill(ctx context.Context, translator Translator, tag language.Tag) ([]Message, error) {
+	mu.RLock()
+	b, fallback := bundle, defaultLanguage
+	mu.RUnlock()
+	if b == nil {
+		panic(ErrI18nNotInitialized)
+	}
+
+	translated := make(map[string]bool)
+	for _, message := range Messages(tag) {
+		translated[message.ID] = true
+	}
+
+	leftDelim, rightDelim := catalogDelims()
+	var (
+		drafts    []Message
+		texts     []string
+		protected [][]string
+	)
+	for _, message := range Messages(fallback) {
+		if translated[message.ID] {
+			continue
+		}
+		draft := message
+		draft.Language = tag
+		draft.Path = ""
+		draft.Description = NeedsReview
+		if message.Description != "" {
+			draft.Description += ": " + message.Description
+		}
+		drafts = append(drafts, draft)
+		for _, form := range message.forms() {
+			if form.text != "" {
+				text, segments := protectText(message.withDelims(leftDelim, rightDelim), form.text)
+				texts = append(texts, text)
+				protected = append(protected, segments)
+			}
+		}
+	}
+	if len(texts) == 0 {
+		return drafts, nil
+	}
+
+	translations, err := translator.Translate(ctx, fallback, tag, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(translations) != len(texts) {
+		return nil, fmt.Errorf("translator returned %d translations for %d texts", len(translations), len(texts))
+	}
+
+	i := 0
+	for d := range drafts {
+		for _, text := range []*string{&drafts[d].Zero, &drafts[d].One, &drafts[d].Two, &drafts[d].Few, &drafts[d].Many, &drafts[d].Other} {
+			if *text == "" {
+				continue
+			}
+			restored, err := restoreText(translations[i], protected[i])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", drafts[d].ID, err)
+			}
+			*text = restored
+			i++
+		}
+	}
+	return drafts, nil
+}
+
+// protectText replaces the message references, template actions and markup of the text with <x id="n"/> tags.
+func protectText(m Message, text string) (string, []string) {
+	pattern := translationProtected
+	if m.LeftDelim != "" || m.RightDelim != "" {
+		pattern = translationProtectedRegexp(m.LeftDelim, m.RightDelim)
+	}
+	var segments []string
+	text = pattern.ReplaceAllStringFunc(text, func(segment string) string {
+		segments = append(segments, segment)
+		return `<x id="` + strconv.Itoa(len(segments)-1) + `"/>`
+	})
+	return text, segments
+}
+
+// restoreText replaces the <x id="n"/> tags of the translation with the protected segments.
+// Every segment must be kept exactly once.
+func restoreText(text string, segments []string) (string, error) {
+	used := make([]bool, len(segments))
+	var err error
+	text = protectedTagRegexp.ReplaceAllStringFunc(text, func(tag string) string {
+		index, _ := strconv.Atoi(protectedTagRegexp.FindStringSubmatch(tag)[1])
+		if index >= len(segments) || used[index] {
+			err = fmt.Errorf("unexpected placeholder %s in %q", tag, text)
+			return tag
+		}
+		used[index] = true
+		return segments[index]
+	})
+	if err != nil {
+		return "", err
+	}
+	for index, ok := range used {
+		if !ok {
+			return "", fmt.Errorf("translator dropped %s in %q", segments[index], text)
+		}
+	}
+	return text, nil
+}
@@ -0,0 +1,128 @@
+package i18n_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestFill(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithMessageFile(
+			&goi18n.MessageFile{
+				Path: "en.yaml",
+				Tag:  language.English,
+				Messages: []*goi18n.Message{
+					{ID: "apple", Description: "Number of apples", One: "{{.PluralCount}} apple", Other: "{{.PluralCount}} apples"},
+					{ID: "brand", Other: "Acme"},
+					{ID: "hello", Other: "Hello, {{.name}}!"},
+					{ID: "terms", Other: `Read the <a href="{{.url}}">terms</a>`},
+					{ID: "welcome", Other: "Welcome to $t(brand)"},
+				},
+			},
+			&goi18n.MessageFile{
+				Path: "id.yaml",
+				Tag:  language.Indonesian,
+				Messages: []*goi18n.Message{
+					{ID: "apple", One: "{{.PluralCount}} apel", Other: "{{.PluralCount}} apel"},
+					{ID: "brand", Other: "Acme"},
+					{ID: "hello", Other: "Halo, {{.name}}!"},
+					{ID: "terms", Other: `Baca <a href="{{.url}}">ketentuan</a>`},
+				},
+			},
+		),
+	)
+	require.NoError(t, err)
+
+	t.Run("missing messages", func(t *testing.T) {
+		drafts, err := i18n.Fill(context.Background(), i18n.FakeTranslator{}, language.Indonesian)
+		require.NoError(t, err)
+		assert.Equal(t, []i18n.Message{{
+			ID:          "welcome",
+			Language:    language.Indonesian,
+			Description: i18n.NeedsReview,
+			Other:       "[id] Welcome to $t(brand)",
+		}}, drafts)
+	})
+
+	t.Run("new language", func(t *testing.T) {
+		var texts []string
+		translator := i18n.TranslatorFunc(func(ctx context.Context, from, to language.Tag, input []string) ([]string, error) {
+			texts = input
+			return i18n.FakeTranslator{}.Translate(ctx, from, to, input)
+		})
+		drafts, err := i18n.Fill(context.Background(), translator, language.Japanese)
+		require.NoError(t, err)
+		require.Len(t, drafts, 5)
+		assert.Equal(t, []string{
+			`<x id="0"/> apple`,
+			`<x id="0"/> apples`,
+			"Acme",
+			`Hello, <x id="0"/>!`,
+			`Read the <x id="0"/>terms<x id="1"/>`,
+			`Welcome to <x id="0"/>`,
+		}, texts)
+
+		byID := make(map[string]i18n.Message)
+		for _, draft := range drafts {
+			byID[draft.ID] = draft
+		}
+		assert.Equal(t, "needs-review: Number of apples", byID["apple"].Description)
+		assert.Equal(t, "[ja] {{.PluralCount}} apple", byID["apple"].One)
+		assert.Equal(t, "[ja] {{.PluralCount}} apples", byID["apple"].Other)
+		assert.Equal(t, `[ja] Read the <a href="{{.url}}">terms</a>`, byID["terms"].Other)
+		assert.Equal(t, "[ja] Welcome to $t(brand)", byID["welcome"].Other)
+	})
+
+	testCases := []struct {
+		name       string
+		translator i18n.TranslatorFunc
+		expected   string
+	}{
+		{
+			name: "dropped placeholder",
+			translator: func(_ context.Context, _, _ language.Tag, texts []string) ([]string, error) {
+				assert.Equal(t, `<x id="0"/> apple`, texts[0])
+				translations := append([]string(nil), texts...)
+				translations[0] = "apple"
+				return translations, nil
+			},
+			expected: `apple: translator dropped {{.PluralCount}} in "apple"`,
+		},
+		{
+			name: "unexpected placeholder",
+			translator: func(_ context.Context, _, _ language.Tag, texts []string) ([]string, error) {
+				translations := append([]string(nil), texts...)
+				translations[0] = `<x id="0"/> <x id="1"/> apple`
+				return translations, nil
+			},
+			expected: `apple: unexpected placeholder <x id="1"/> in "<x id=\"0\"/> <x id=\"1\"/> apple"`,
+		},
+		{
+			name: "wrong number of translations",
+			translator: func(_ context.Context, _, _ language.Tag, texts []string) ([]string, error) {
+				return texts[:1], nil
+			},
+			expected: "translator returned 1 translations for 6 texts",
+		},
+		{
+			name: "provider error",
+			translator: func(_ context.Context, _, _ language.Tag, _ []string) ([]string, error) {
+				return nil, errors.New("quota exceeded")
+			},
+			expected: "quota exceeded",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := i18n.Fill(context.Background(), tc.translator, language.Japanese)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// MergePolicy decides which message is kept when several files define the same message id for a language.
+type MergePolicy int
+
+const (
+	// MergeLastWins keeps the message of the last file. It is the default policy.
+	MergeLastWins MergePolicy = iota
+	// MergeFirstWins keeps the message of the first file, so later files can not overwrite it.
+	MergeFirstWins
+	// MergeError makes Init fail with ErrMessageConflict.
+	MergeError
+	// MergeWarn keeps the message of the last file and logs the conflict with the log package.
+	MergeWarn
+)
+
+// ErrMessageConflict is returned by Init when several files define the same message with the MergeError policy.
+var ErrMessageConflict = errors.New("conflicting message definitions")
+
+// Conflict is a message id that is defined differently by several files for the same language.
+type Conflict struct {
+	ID       string
+	Language language.Tag
+	// Files are the paths of the files that define the message in load order.
+	Files []string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s (%s) is defined in %s", c.ID, c.Language, strings.Join(c.Files, ", "))
+}
+
+// WithMergePolicy sets the policy for the messages that are defined by several files loaded by Init.
+//
+// The translation files, the message files and the sources are merged in this order.
+// The messages reloaded by Refresh always replace the loaded messages.
+//
+// Example:
+//
+//	i18n.Init(language.English,
+//		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+//		i18n.WithTranslationFile("locales/en.yaml", "vendor/lib/en.yaml"),
+//		i18n.WithMergePolicy(i18n.MergeFirstWins),
+//	)
+func WithMergePolicy(policy MergePolicy) Option {
+	return func(c *config) {
+		c.mergePolicy = policy
+	}
+}
+
+// Conflicts returns the messages that are defined differently by several files loaded by Init,
+// sorted by language and id.
+//
+// Example:
+//
+//	for _, conflict := range i18n.Conflicts() {
+//		fmt.Println(conflict)
+//	}
+func Conflicts() []Conflict {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Conflict(nil), messageConflicts...)
+}
+
+type mergeKey struct {
+	tag language.Tag
+	id  string
+}
+
+// mergeMessageFiles applies the policy to the files and returns the files to add and the conflicts.
+func mergeMessageFiles(files []*i18n.MessageFile, policy MergePolicy) ([]*i18n.MessageFile, []Conflict, error) {
+	first := make(map[mergeKey]*i18n.Message)
+	definitions := make(map[mergeKey]*Conflict)
+	differs := make(map[mergeKey]bool)
+	merged := make([]*i18n.MessageFile, 0, len(files))
+	for _, file := range files {
+		mergedFile := *file
+		mergedFile.Messages = make([]*i18n.Message, 0, len(file.Messages))
+		for _, message := range file.Messages {
+			key := mergeKey{tag: file.Tag, id: message.ID}
+			firstMessage, ok := first[key]
+			if !ok {
+				first[key] = message
+				definitions[key] = &Conflict{ID: message.ID, Language: file.Tag, Files: []string{file.Path}}
+				mergedFile.Messages = append(mergedFile.Messages, message)
+				continue
+			}
+			definitions[key].Files = append(definitions[key].Files, file.Path)
+			if !reflect.DeepEqual(*firstMessage, *message) {
+				differs[key] = true
+			}
+			if policy != MergeFirstWins {
+				mergedFile.Messages = append(mergedFile.Messages, message)
+			}
+		}
+		merged = append(merged, &mergedFile)
+	}
+
+	var report []Conflict
+	for key := range differs {
+		report = append(report, *definitions[key])
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Language != report[j].Language {
+			return report[i].Language.String() < report[j].Language.String()
+		}
+		return report[i].ID < report[j].ID
+	})
+
+	switch policy {
+	case MergeError:
+		if len(report) > 0 {
+			descriptions := make([]string, len(report))
+			for i, conflict := range report {
+				descriptions[i] = conflict.String()
+			}
+			return nil, report, fmt.Errorf("%w: %s", ErrMessageConflict, strings.Join(descriptions, "; "))
+		}
+	case MergeWarn:
+		for _, conflict := range report {
+			log.Printf("i18n: %s", conflict)
+		}
+	}
+	return merged, report, nil
+}
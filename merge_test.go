@@ -0,0 +1,99 @@
+package i18n_test
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+var libraryFile = &goi18n.MessageFile{
+	Path: "lib/en.yaml",
+	Tag:  language.English,
+	Messages: []*goi18n.Message{
+		{ID: "cart", Other: "Shopping bag"},
+		{ID: "hello_world", Other: "Hello, World!"},
+		{ID: "lib_only", Other: "Library message"},
+	},
+}
+
+func TestMergePolicy(t *testing.T) {
+	expectedConflicts := []i18n.Conflict{
+		{ID: "cart", Language: language.English, Files: []string{"testdata/en.yaml", "lib/en.yaml"}},
+	}
+
+	testCases := []struct {
+		name         string
+		policy       i18n.MergePolicy
+		expectedCart string
+		expectedLog  string
+	}{
+		{
+			name:         "last wins",
+			policy:       i18n.MergeLastWins,
+			expectedCart: "Shopping bag",
+		},
+		{
+			name:         "first wins",
+			policy:       i18n.MergeFirstWins,
+			expectedCart: "Cart",
+		},
+		{
+			name:         "warn",
+			policy:       i18n.MergeWarn,
+			expectedCart: "Shopping bag",
+			expectedLog:  "i18n: cart (en) is defined in testdata/en.yaml, lib/en.yaml\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			output, flags := log.Writer(), log.Flags()
+			log.SetOutput(&buf)
+			log.SetFlags(0)
+			defer func() {
+				log.SetOutput(output)
+				log.SetFlags(flags)
+			}()
+
+			err := i18n.Init(language.English,
+				i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+				i18n.WithTranslationFile("testdata/en.yaml"),
+				i18n.WithMessageFile(libraryFile),
+				i18n.WithMergePolicy(tc.policy),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedCart, i18n.T("cart"))
+			assert.Equal(t, "Library message", i18n.T("lib_only"))
+			assert.Equal(t, expectedConflicts, i18n.Conflicts())
+			assert.Equal(t, tc.expectedLog, buf.String())
+		})
+	}
+}
+
+func TestMergePolicyError(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+		i18n.WithMessageFile(libraryFile),
+		i18n.WithMergePolicy(i18n.MergeError),
+	)
+	assert.ErrorIs(t, err, i18n.ErrMessageConflict)
+	assert.EqualError(t, err, "conflicting message definitions: cart (en) is defined in testdata/en.yaml, lib/en.yaml")
+
+	err = i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithMergePolicy(i18n.MergeError),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, i18n.Conflicts())
+}
@@ -0,0 +1,236 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// MessageMetadata is the context of a message for the translators.
+type MessageMetadata struct {
+	// ID is the message id.
+	ID string `json:"id"`
+	// Description describes the message. It defaults to the description of the message in the default language.
+	Description string `json:"description,omitempty" yaml:"description" toml:"description"`
+	// Screenshot is a reference to a screenshot that shows the message, e.g. a path or an URL.
+	Screenshot string `json:"screenshot,omitempty" yaml:"screenshot" toml:"screenshot"`
+	// MaxLength is the maximum number of characters of the translations, without the template actions and markup.
+	// Zero means no limit.
+	MaxLength int `json:"maxLength,omitempty" yaml:"maxLength" toml:"maxLength"`
+	// Tags group the messages, e.g. email or ui.
+	Tags []string `json:"tags,omitempty" yaml:"tags" toml:"tags"`
+}
+
+// ValidationError is a translation that does not satisfy the metadata of its message or references
+// a missing message.
+type ValidationError struct {
+	ID       string
+	Language language.Tag
+	// Form is the plural form of the translation, e.g. one or other.
+	Form   string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s (%s, %s): %s", e.ID, e.Language, e.Form, e.Reason)
+}
+
+type messageInfoFSFile struct {
+	fs    embed.FS
+	paths []string
+}
+
+// WithMessageInfoFile loads the metadata of the messages from the files.
+//
+// The files map the message ids to the metadata and are unmarshaled by the unmarshal function of their extension.
+//
+// Example:
+//
+//	# locales/info.yaml
+//	checkout.title:
+//	  description: Title of the checkout page
+//	  screenshot: screenshots/checkout.png
+//	  maxLength: 24
+//	  tags: [ui]
+func WithMessageInfoFile(paths ...string) Option {
+	return func(c *config) {
+		c.messageInfoFiles = append(c.messageInfoFiles, paths...)
+	}
+}
+
+// WithMessageInfoFSFile loads the metadata of the messages from the files.
+//
+// It is similar to WithMessageInfoFile, but it uses embed.FS as file system.
+func WithMessageInfoFSFile(fs embed.FS, paths ...string) Option {
+	return func(c *config) {
+		c.messageInfoFSFiles = append(c.messageInfoFSFiles, messageInfoFSFile{fs: fs, paths: paths})
+	}
+}
+
+// MessageInfo returns the metadata of the message.
+//
+// It returns false when the message has neither metadata nor a message in the default language.
+//
+// Example:
+//
+//	info, ok := i18n.MessageInfo("checkout.title")
+//	if ok && info.MaxLength > 0 {
+//		input.MaxLength = info.MaxLength
+//	}
+func MessageInfo(id string) (MessageMetadata, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if bundle == nil {
+		panic(ErrI18nNotInitialized)
+	}
+
+	info := MessageMetadata{ID: id}
+	metadata, hasMetadata := messageInfos[id]
+	if hasMetadata {
+		info = *metadata
+		info.Tags = append([]string(nil), metadata.Tags...)
+	}
+	entry, hasMessage := messageCatalog.entries[defaultLanguage][id]
+	if hasMessage && info.Description == "" {
+		info.Description = entry.message.Description
+	}
+	return info, hasMetadata || hasMessage
+}
+
+// Validate checks the translations of every language against the metadata of the messages and their references.
+//
+// It returns the translations that are longer than the MaxLength of their message, the references to missing
+// messages and the reference cycles, sorted by language, id and form.
+//
+// Example:
+//
+//	for _, err := range i18n.Validate() {
+//		log.Println(err)
+//	}
+func Validate() []*ValidationError {
+	mu.RLock()
+	defer mu.RUnlock()
+	if bundle == nil {
+		panic(ErrI18nNotInitialized)
+	}
+
+	errs := messageCatalog.unresolvedReferences(defaultLanguage)
+	errs = append(errs, messageCatalog.referenceCycles(defaultLanguage)...)
+	for tag, entries := range messageCatalog.entries {
+		for id, entry := range entries {
+			info, ok := messageInfos[id]
+			if !ok || info.MaxLength <= 0 {
+				continue
+			}
+			for _, form := range messageForms(entry.prepared) {
+				length := textLength(entry.prepared, form.text, messageCatalog.leftDelim, messageCatalog.rightDelim)
+				if length > info.MaxLength {
+					errs = append(errs, &ValidationError{
+						ID:       id,
+						Language: tag,
+						Form:     form.name,
+						Reason:   fmt.Sprintf("%d characters exceed the max length of %d", length, info.MaxLength),
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		if errs[i].Language != errs[j].Language {
+			return errs[i].Language.String() < errs[j].Language.String()
+		}
+		if errs[i].ID != errs[j].ID {
+			return errs[i].ID < errs[j].ID
+		}
+		if errs[i].Form != errs[j].Form {
+			return errs[i].Form < errs[j].Form
+		}
+		return errs[i].Reason < errs[j].Reason
+	})
+	return errs
+}
+
+type messageForm struct {
+	name string
+	text string
+}
+
+func messageForms(message *i18n.Message) []messageForm {
+	var forms []messageForm
+	for _, form := range []messageForm{
+		{name: "zero", text: message.Zero},
+		{name: "one", text: message.One},
+		{name: "two", text: message.Two},
+		{name: "few", text: message.Few},
+		{name: "many", text: message.Many},
+		{name: "other", text: message.Other},
+	} {
+		if form.text != "" {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// textLength returns the number of characters of the text without the template actions and markup.
+func textLength(message *i18n.Message, text, leftDelim, rightDelim string) int {
+	leftDelim, rightDelim = messageDelims(message, leftDelim, rightDelim)
+	return utf8.RuneCountInString(pseudoProtectedRegexp(leftDelim, rightDelim).ReplaceAllString(text, ""))
+}
+
+func readMessageInfoFiles(config *config) (map[string]*MessageMetadata, error) {
+	infos := make(map[string]*MessageMetadata)
+	read := func(buf []byte, path string) error {
+		format := strings.TrimPrefix(filepath.Ext(path), ".")
+		unmarshalFunc, ok := config.unmarshalFuncMap[format]
+		if !ok && format == "json" {
+			unmarshalFunc, ok = json.Unmarshal, true
+		}
+		if !ok {
+			return fmt.Errorf("no unmarshal function registered for the format %q of %s", format, path)
+		}
+		var fileInfos map[string]*MessageMetadata
+		if err := unmarshalFunc(buf, &fileInfos); err != nil {
+			return err
+		}
+		for id, info := range fileInfos {
+			if info == nil {
+				info = &MessageMetadata{}
+			}
+			info.ID = id
+			infos[id] = info
+		}
+		return nil
+	}
+
+	for _, path := range config.messageInfoFiles {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := read(buf, path); err != nil {
+			return nil, err
+		}
+	}
+	for _, fsFile := range config.messageInfoFSFiles {
+		for _, path := range fsFile.paths {
+			buf, err := fs.ReadFile(fsFile.fs, path)
+			if err != nil {
+				return nil, err
+			}
+			if err := read(buf, path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return infos, nil
+}
@@ -0,0 +1,93 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/ahmadfaizk/i18n/testdata"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMessageInfo(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithMessageFile(&goi18n.MessageFile{
+			Path:     "described.yaml",
+			Tag:      language.English,
+			Messages: []*goi18n.Message{{ID: "described", Description: "Shown on the home page", Other: "Welcome"}},
+		}),
+		i18n.WithMessageInfoFile("testdata/info.yaml"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		messageID string
+		expected  i18n.MessageMetadata
+		ok        bool
+	}{
+		{
+			name:      "metadata",
+			messageID: "cart",
+			expected: i18n.MessageMetadata{
+				ID:          "cart",
+				Description: "Label of the cart button",
+				Screenshot:  "screenshots/header.png",
+				MaxLength:   8,
+				Tags:        []string{"ui"},
+			},
+			ok: true,
+		},
+		{
+			name:      "description of the message",
+			messageID: "described",
+			expected:  i18n.MessageMetadata{ID: "described", Description: "Shown on the home page"},
+			ok:        true,
+		},
+		{
+			name:      "message without metadata",
+			messageID: "hello",
+			expected:  i18n.MessageMetadata{ID: "hello"},
+			ok:        true,
+		},
+		{
+			name:      "not found",
+			messageID: "not_found",
+			expected:  i18n.MessageMetadata{ID: "not_found"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info, ok := i18n.MessageInfo(tc.messageID)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, info)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithMessageInfoFSFile(testdata.FS, "info.yaml"),
+	)
+	require.NoError(t, err)
+
+	errs := i18n.Validate()
+	assert.Equal(t, []*i18n.ValidationError{
+		{ID: "hello_age", Language: language.English, Form: "other", Reason: "28 characters exceed the max length of 27"},
+		{ID: "cart", Language: language.Indonesian, Form: "other", Reason: "9 characters exceed the max length of 8"},
+	}, errs)
+	assert.EqualError(t, errs[1], "cart (id, other): 9 characters exceed the max length of 8")
+}
+
+func TestMessageInfoFileError(t *testing.T) {
+	err := i18n.Init(language.English, i18n.WithMessageInfoFile("testdata/info.yaml"))
+	assert.EqualError(t, err, `no unmarshal function registered for the format "yaml" of testdata/info.yaml`)
+}
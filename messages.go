@@ -0,0 +1,121 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+// Message is a raw message of the bundle with its templates as they are written in the translation file.
+type Message struct {
+	ID       string
+	Language language.Tag
+	// Description describes the message for the translators.
+	Description string
+	// LeftDelim and RightDelim are the delimiters of the template actions. They default to {{ and }}.
+	LeftDelim  string
+	RightDelim string
+	// Zero, One, Two, Few, Many and Other are the templates of the plural forms.
+	// A message without plural forms only has Other.
+	Zero  string
+	One   string
+	Two   string
+	Few   string
+	Many  string
+	Other string
+	// Path is the path of the file that defines the message.
+	Path string
+}
+
+// withDelims returns the message with the delimiters when it has no delimiters of its own.
+func (m Message) withDelims(leftDelim, rightDelim string) Message {
+	if m.LeftDelim == "" && m.RightDelim == "" {
+		m.LeftDelim, m.RightDelim = leftDelim, rightDelim
+	}
+	return m
+}
+
+func newMessage(tag language.Tag, entry *catalogEntry) Message {
+	return Message{
+		ID:          entry.message.ID,
+		Language:    tag,
+		Description: entry.message.Description,
+		LeftDelim:   entry.message.LeftDelim,
+		RightDelim:  entry.message.RightDelim,
+		Zero:        entry.message.Zero,
+		One:         entry.message.One,
+		Two:         entry.message.Two,
+		Few:         entry.message.Few,
+		Many:        entry.message.Many,
+		Other:       entry.message.Other,
+		Path:        entry.path,
+	}
+}
+
+// Messages returns the raw messages of the language sorted by id.
+//
+// The language must match a language of the bundle exactly, see Languages.
+//
+// Example:
+//
+//	for _, message := range i18n.Messages(language.Indonesian) {
+//		fmt.Println(message.ID, message.Other)
+//	}
+func Messages(tag language.Tag) []Message {
+	mu.RLock()
+	defer mu.RUnlock()
+	if bundle == nil {
+		panic(ErrI18nNotInitialized)
+	}
+
+	entries := messageCatalog.entries[tag]
+	messages := make([]Message, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, newMessage(tag, entry))
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+	return messages
+}
+
+// Lookup returns the raw message of the language without falling back to the default language.
+//
+// Example:
+//
+//	message, ok := i18n.Lookup("apple", language.English)
+//	fmt.Println(message.One, message.Other)
+func Lookup(id string, tag language.Tag) (Message, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if bundle == nil {
+		panic(ErrI18nNotInitialized)
+	}
+
+	entry, ok := messageCatalog.entries[tag][id]
+	if !ok {
+		return Message{}, false
+	}
+	return newMessage(tag, entry), true
+}
+
+// Has reports whether GetCtx finds the message for the context, in the language of the context
+// or in the default language. It does not call the missing translation handler.
+//
+// Example:
+//
+//	if i18n.Has(ctx, "promo.banner") {
+//		banner = i18n.GetCtx(ctx, "promo.banner")
+//	}
+func Has(ctx context.Context, id string) bool {
+	tag := resolveLanguage(ctx)
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if _, ok := messageCatalog.entries[tag][id]; ok {
+		return true
+	}
+	_, ok := messageCatalog.entries[defaultLanguage][id]
+	return ok
+}
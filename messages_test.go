@@ -0,0 +1,97 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMessages(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/ar.yaml"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []i18n.Message{
+		{ID: "hello", Language: language.Arabic, Other: "مرحبا {{.name}}", Path: "testdata/ar.yaml"},
+		{ID: "hello_world", Language: language.Arabic, Other: "مرحبا بالعالم", Path: "testdata/ar.yaml"},
+	}, i18n.Messages(language.Arabic))
+	assert.Len(t, i18n.Messages(language.English), 9)
+	assert.Empty(t, i18n.Messages(language.Indonesian))
+}
+
+func TestLookup(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		messageID string
+		language  language.Tag
+		expected  i18n.Message
+		ok        bool
+	}{
+		{
+			name:      "plural forms",
+			messageID: "apple",
+			language:  language.English,
+			expected: i18n.Message{
+				ID:       "apple",
+				Language: language.English,
+				One:      "{{.PluralCount}} apple",
+				Other:    "{{.PluralCount}} apples",
+				Path:     "testdata/en.yaml",
+			},
+			ok: true,
+		},
+		{
+			name:      "raw template",
+			messageID: "hello",
+			language:  language.Indonesian,
+			expected:  i18n.Message{ID: "hello", Language: language.Indonesian, Other: "Halo {{.name}}", Path: "testdata/id.yaml"},
+			ok:        true,
+		},
+		{
+			name:      "no fallback to default language",
+			messageID: "only_in_en",
+			language:  language.Indonesian,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			message, ok := i18n.Lookup(tc.messageID, tc.language)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, message)
+		})
+	}
+}
+
+func TestHas(t *testing.T) {
+	var missing []string
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithMissingTranslationHandler(func(id string, err error) string {
+			missing = append(missing, id)
+			return id
+		}),
+	)
+	require.NoError(t, err)
+
+	ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+	assert.True(t, i18n.Has(ctx, "hello_world"))
+	assert.True(t, i18n.Has(ctx, "only_in_en"))
+	assert.False(t, i18n.Has(ctx, "not_found"))
+	assert.False(t, i18n.Has(context.Background(), "not_found"))
+	assert.Empty(t, missing)
+}
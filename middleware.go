@@ -12,6 +12,15 @@ type contextKey string
 
 const languageCtxKey contextKey = "i18n-language"
 
+// LanguageContextKey is the context key of the language set by NewContextWithLanguage.
+//
+// It is useful for the frameworks that store the values in their own context, e.g. the user values of fasthttp.
+//
+// Example:
+//
+//	c.Context().SetUserValue(i18n.LanguageContextKey, "id")
+const LanguageContextKey = languageCtxKey
+
 // Middleware is a middleware that sets the language to the context from the request.
 //
 // It uses the Accept-Language header to get the language.
@@ -42,6 +51,20 @@ func GetLanguage(ctx context.Context) language.Tag {
 	return tags[0]
 }
 
+// ExtractLanguage returns the language of the context as it is used by GetCtx.
+//
+// It uses the function set by WithExtractLanguageFunc. If the language is not found, it returns an empty string.
+// It is useful to forward the language to other services.
+func ExtractLanguage(ctx context.Context) string {
+	mu.RLock()
+	extract := extractLanguageFunc
+	mu.RUnlock()
+	if extract == nil {
+		extract = defaultExtractLanguageFunc
+	}
+	return extract(ctx)
+}
+
 // NewContextWithLanguage sets the language to the context.
 //
 // You can use this function to set the language to the context manually.
@@ -153,3 +153,19 @@ func TestGetLanguage(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractLanguage(t *testing.T) {
+	err := i18n.Init(language.English)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", i18n.ExtractLanguage(context.Background()))
+	assert.Equal(t, "id-ID,en", i18n.ExtractLanguage(i18n.NewContextWithLanguage(context.Background(), "id-ID,en")))
+
+	type langKey struct{}
+	err = i18n.Init(language.English, i18n.WithExtractLanguageFunc(func(ctx context.Context) string {
+		lang, _ := ctx.Value(langKey{}).(string)
+		return lang
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "id", i18n.ExtractLanguage(context.WithValue(context.Background(), langKey{}, "id")))
+}
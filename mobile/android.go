@@ -0,0 +1,137 @@
+package mobile
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+type androidResources struct {
+	XMLName xml.Name        `xml:"resources"`
+	Strings []androidString `xml:"string"`
+	Plurals []androidPlural `xml:"plurals"`
+}
+
+type androidString struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",innerxml"`
+}
+
+type androidPlural struct {
+	Name  string              `xml:"name,attr"`
+	Items []androidPluralItem `xml:"item"`
+}
+
+type androidPluralItem struct {
+	Quantity string `xml:"quantity,attr"`
+	Value    string `xml:",innerxml"`
+}
+
+var (
+	androidUnescaper = strings.NewReplacer(`\'`, `'`, `\"`, `"`, `\n`, "\n", `\t`, "\t", `\@`, `@`, `\?`, `?`, `\\`, `\`)
+	androidEscaper   = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	xmlTextEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// ParseAndroidStrings parses an Android strings.xml file.
+//
+// Both <string> and <plurals> resources are read, other resources are ignored.
+func ParseAndroidStrings(buf []byte, tag language.Tag) (*i18n.MessageFile, error) {
+	var resources androidResources
+	if err := xml.Unmarshal(buf, &resources); err != nil {
+		return nil, err
+	}
+
+	file := &i18n.MessageFile{Tag: tag, Format: "xml"}
+	for _, s := range resources.Strings {
+		file.Messages = append(file.Messages, &i18n.Message{
+			ID:    s.Name,
+			Other: fromPrintf(unescapeAndroid(s.Value), 0, 0),
+		})
+	}
+	for _, p := range resources.Plurals {
+		message := &i18n.Message{ID: p.Name}
+		for _, item := range p.Items {
+			// The plural count is the first integer argument, e.g. %2$d in "%1$s has %2$d files".
+			value := unescapeAndroid(item.Value)
+			if !setPluralForm(message, item.Quantity, fromPrintf(value, 0, integerArgument(value))) {
+				return nil, fmt.Errorf("invalid quantity %q of plurals %q", item.Quantity, p.Name)
+			}
+		}
+		file.Messages = append(file.Messages, message)
+	}
+	return file, nil
+}
+
+func unescapeAndroid(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "<![CDATA[") && strings.HasSuffix(s, "]]>") {
+		s = strings.TrimSuffix(strings.TrimPrefix(s, "<![CDATA["), "]]>")
+	} else {
+		s = html.UnescapeString(s)
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	return androidUnescaper.Replace(s)
+}
+
+func escapeAndroid(s string) string {
+	escaped := xmlTextEscaper.Replace(androidEscaper.Replace(s))
+	if strings.HasPrefix(escaped, "@") || strings.HasPrefix(escaped, "?") {
+		escaped = `\` + escaped
+	}
+	return escaped
+}
+
+// escapeXMLAttr escapes the value of an XML attribute.
+func escapeXMLAttr(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func androidPlaceholder(plural bool) func(name string, index int) string {
+	return func(name string, index int) string {
+		if plural && name == pluralCountParam {
+			return fmt.Sprintf("%%%d$d", index)
+		}
+		return fmt.Sprintf("%%%d$s", index)
+	}
+}
+
+// WriteAndroidStrings writes the messages as an Android strings.xml file.
+//
+// Plural messages are written as <plurals> resources.
+func WriteAndroidStrings(w io.Writer, file *i18n.MessageFile) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString("<resources>\n")
+	for _, message := range file.Messages {
+		if !isPlural(message) {
+			indexes := printfIndexes([]string{message.Other}, false)
+			value := escapeAndroid(toPrintf(message.Other, indexes, androidPlaceholder(false)))
+			fmt.Fprintf(&b, "    <string name=\"%s\">%s</string>\n", escapeXMLAttr(message.ID), value)
+			continue
+		}
+		fmt.Fprintf(&b, "    <plurals name=\"%s\">\n", escapeXMLAttr(message.ID))
+		forms := pluralForms(message)
+		indexes := printfIndexes(formTexts(forms), true)
+		for _, category := range pluralCategories {
+			if form, ok := forms[category]; ok {
+				value := escapeAndroid(toPrintf(form, indexes, androidPlaceholder(true)))
+				fmt.Fprintf(&b, "        <item quantity=\"%s\">%s</item>\n", category, value)
+			}
+		}
+		b.WriteString("    </plurals>\n")
+	}
+	b.WriteString("</resources>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
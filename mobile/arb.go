@@ -0,0 +1,136 @@
+package mobile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+type arbMetadata struct {
+	Description  string                    `json:"description,omitempty"`
+	Placeholders map[string]arbPlaceholder `json:"placeholders,omitempty"`
+}
+
+type arbPlaceholder struct {
+	Type string `json:"type,omitempty"`
+}
+
+// ParseARB parses a Flutter ARB file.
+//
+// The language is read from the "@@locale" key. If the key is not set, the given tag is used.
+// The description of the "@key" metadata is set as the message description.
+func ParseARB(buf []byte, tag language.Tag) (*i18n.MessageFile, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return nil, err
+	}
+
+	file := &i18n.MessageFile{Tag: tag, Format: "arb"}
+	if locale, ok := raw["@@locale"]; ok {
+		var value string
+		if err := json.Unmarshal(locale, &value); err != nil {
+			return nil, fmt.Errorf("invalid @@locale: %w", err)
+		}
+		localeTag, err := language.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid @@locale: %w", err)
+		}
+		file.Tag = localeTag
+	}
+
+	ids := make([]string, 0, len(raw))
+	for id := range raw {
+		if id == "" {
+			return nil, errors.New("empty message id")
+		}
+		if !strings.HasPrefix(id, "@") {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		var value string
+		if err := json.Unmarshal(raw[id], &value); err != nil {
+			return nil, fmt.Errorf("invalid value of %q: %w", id, err)
+		}
+		forms, err := fromICU(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %q: %w", id, err)
+		}
+		message := &i18n.Message{ID: id}
+		for category, form := range forms {
+			setPluralForm(message, category, form)
+		}
+		if meta, ok := raw["@"+id]; ok {
+			var metadata arbMetadata
+			if err := json.Unmarshal(meta, &metadata); err != nil {
+				return nil, fmt.Errorf("invalid metadata of %q: %w", id, err)
+			}
+			message.Description = metadata.Description
+		}
+		file.Messages = append(file.Messages, message)
+	}
+	return file, nil
+}
+
+// WriteARB writes the messages as a Flutter ARB file.
+//
+// Every message is followed by its "@key" metadata with the description and placeholders.
+func WriteARB(w io.Writer, file *i18n.MessageFile) error {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	writeEntry := func(key string, value interface{}, last bool) error {
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		v, err := json.MarshalIndent(value, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		buf.WriteString("  ")
+		buf.Write(k)
+		buf.WriteString(": ")
+		buf.Write(v)
+		if !last {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+		return nil
+	}
+
+	if err := writeEntry("@@locale", file.Tag.String(), len(file.Messages) == 0); err != nil {
+		return err
+	}
+	for i, message := range file.Messages {
+		if err := writeEntry(message.ID, toICU(message), false); err != nil {
+			return err
+		}
+		metadata := arbMetadata{Description: message.Description}
+		for _, param := range icuParams(message) {
+			if metadata.Placeholders == nil {
+				metadata.Placeholders = map[string]arbPlaceholder{}
+			}
+			placeholder := arbPlaceholder{}
+			if param == "count" && isPlural(message) {
+				placeholder.Type = "num"
+			}
+			metadata.Placeholders[param] = placeholder
+		}
+		if err := writeEntry("@"+message.ID, metadata, i == len(file.Messages)-1); err != nil {
+			return err
+		}
+	}
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,20 @@
+// Package mobile reads and writes the string resources used by mobile platforms.
+//
+// It supports Flutter ARB files, Android strings.xml and iOS .strings/.stringsdict files.
+// The placeholders of each format are converted to the Go templates used by i18n.GetCtx,
+// so the same catalog can be shared between the Go backend and the mobile apps.
+//
+// Named placeholders (ARB) keep their name, e.g. "{name}" becomes "{{.name}}".
+// Positional placeholders (Android and iOS) are named by their position, e.g. "%1$s" becomes "{{.arg1}}".
+// In plural messages the plural count is "{{.PluralCount}}", which is set by i18n.Plural.
+//
+// Example:
+//
+//	file, err := mobile.LoadFile(os.DirFS("res"), "values-id/strings.xml", language.English)
+//	if err != nil {
+//		panic(err)
+//	}
+//	if err := i18n.Init(language.English, i18n.WithMessageFile(file)); err != nil {
+//		panic(err)
+//	}
+package mobile
@@ -0,0 +1,87 @@
+package mobile
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// LanguageFromPath returns the language of the resource file from its path.
+//
+// It understands the Flutter (app_en.arb), Android (values-en-rUS/strings.xml)
+// and iOS (en.lproj/Localizable.strings) conventions. The fallback tag is returned
+// for the default resources (values, Base.lproj) or when the language can not be determined.
+func LanguageFromPath(filePath string, fallback language.Tag) language.Tag {
+	filePath = path.Clean(strings.ReplaceAll(filePath, "\\", "/"))
+	dir := path.Base(path.Dir(filePath))
+	base := path.Base(filePath)
+
+	var lang string
+	switch {
+	case strings.HasSuffix(base, ".arb"):
+		name := strings.TrimSuffix(base, ".arb")
+		if i := strings.IndexByte(name, '_'); i >= 0 {
+			lang = strings.ReplaceAll(name[i+1:], "_", "-")
+		}
+	case strings.HasSuffix(dir, ".lproj"):
+		lang = strings.TrimSuffix(dir, ".lproj")
+	case strings.HasPrefix(dir, "values-b+"):
+		lang = strings.ReplaceAll(strings.TrimPrefix(dir, "values-b+"), "+", "-")
+	case strings.HasPrefix(dir, "values-"):
+		qualifiers := strings.Split(strings.TrimPrefix(dir, "values-"), "-")
+		lang = qualifiers[0]
+		if len(qualifiers) > 1 && len(qualifiers[1]) == 3 && qualifiers[1][0] == 'r' {
+			lang += "-" + qualifiers[1][1:]
+		}
+	}
+
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return fallback
+	}
+	return tag
+}
+
+// LoadFile reads and parses the resource file from the file system.
+//
+// The format is chosen by the file extension and the language by LanguageFromPath.
+func LoadFile(fsys fs.FS, filePath string, fallback language.Tag) (*i18n.MessageFile, error) {
+	buf, err := fs.ReadFile(fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := LanguageFromPath(filePath, fallback)
+	var file *i18n.MessageFile
+	switch ext := path.Ext(filePath); ext {
+	case ".arb":
+		file, err = ParseARB(buf, tag)
+	case ".xml":
+		file, err = ParseAndroidStrings(buf, tag)
+	case ".strings":
+		file, err = ParseStrings(buf, tag)
+	case ".stringsdict":
+		file, err = ParseStringsDict(buf, tag)
+	default:
+		return nil, fmt.Errorf("unsupported file format %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	file.Path = filePath
+	return file, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,287 @@
+package mobile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var (
+	stringsUnescaper  = strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\n`, "\n", `\t`, "\t", `\r`, "\r")
+	stringsEscaper    = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	unicodeEscape     = regexp.MustCompile(`\\[Uu]([0-9a-fA-F]{4})`)
+	formatVariable    = regexp.MustCompile(`%(?:(\d+)\$)?#@(\w+)@`)
+	errInvalidStrings = errors.New("invalid .strings file")
+)
+
+// ParseStrings parses an iOS .strings file.
+//
+// The comment before an entry is set as the message description.
+func ParseStrings(buf []byte, tag language.Tag) (*i18n.MessageFile, error) {
+	file := &i18n.MessageFile{Tag: tag, Format: "strings"}
+	s := strings.TrimPrefix(string(buf), "\uFEFF")
+	var comment string
+	for i := 0; ; {
+		for i < len(s) && strings.IndexByte(" \t\r\n", s[i]) >= 0 {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		switch {
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated comment", errInvalidStrings)
+			}
+			comment = strings.TrimSpace(s[i+2 : i+2+end])
+			i += end + 4
+			continue
+		case strings.HasPrefix(s[i:], "//"):
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				end = len(s) - i
+			}
+			comment = strings.TrimSpace(s[i+2 : i+end])
+			i += end
+			continue
+		}
+
+		key, next, err := readStringsToken(s, i)
+		if err != nil {
+			return nil, err
+		}
+		i = skipSpaces(s, next)
+		if i >= len(s) || s[i] != '=' {
+			return nil, fmt.Errorf("%w: expected '=' after %q", errInvalidStrings, key)
+		}
+		value, next, err := readStringsToken(s, skipSpaces(s, i+1))
+		if err != nil {
+			return nil, err
+		}
+		i = skipSpaces(s, next)
+		if i >= len(s) || s[i] != ';' {
+			return nil, fmt.Errorf("%w: expected ';' after %q", errInvalidStrings, key)
+		}
+		i++
+
+		file.Messages = append(file.Messages, &i18n.Message{
+			ID:          key,
+			Description: comment,
+			Other:       fromPrintf(value, 0, 0),
+		})
+		comment = ""
+	}
+	return file, nil
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && strings.IndexByte(" \t\r\n", s[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+func readStringsToken(s string, i int) (string, int, error) {
+	if i >= len(s) {
+		return "", i, fmt.Errorf("%w: unexpected end of file", errInvalidStrings)
+	}
+	if s[i] != '"' {
+		start := i
+		for i < len(s) && strings.IndexByte(" \t\r\n=;", s[i]) < 0 {
+			i++
+		}
+		if start == i {
+			return "", i, fmt.Errorf("%w: unexpected %q", errInvalidStrings, s[i])
+		}
+		return s[start:i], i, nil
+	}
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '"':
+			return unescapeStrings(s[i+1 : j]), j + 1, nil
+		}
+	}
+	return "", i, fmt.Errorf("%w: unterminated string", errInvalidStrings)
+}
+
+func unescapeStrings(s string) string {
+	s = unicodeEscape.ReplaceAllStringFunc(s, func(match string) string {
+		r, _ := strconv.ParseUint(match[2:], 16, 32)
+		buf := make([]byte, utf8.UTFMax)
+		return string(buf[:utf8.EncodeRune(buf, rune(r))])
+	})
+	return stringsUnescaper.Replace(s)
+}
+
+func iosPlaceholder(plural bool) func(name string, index int) string {
+	return func(name string, index int) string {
+		if plural && name == pluralCountParam {
+			return fmt.Sprintf("%%%d$d", index)
+		}
+		return fmt.Sprintf("%%%d$@", index)
+	}
+}
+
+// WriteStrings writes the messages as an iOS .strings file.
+//
+// Plural messages are skipped, use WriteStringsDict to write them.
+func WriteStrings(w io.Writer, file *i18n.MessageFile) error {
+	var b strings.Builder
+	for _, message := range file.Messages {
+		if isPlural(message) {
+			continue
+		}
+		if message.Description != "" {
+			fmt.Fprintf(&b, "/* %s */\n", strings.ReplaceAll(message.Description, "*/", "* /"))
+		}
+		value := toPrintf(message.Other, printfIndexes([]string{message.Other}, false), iosPlaceholder(false))
+		fmt.Fprintf(&b, "\"%s\" = \"%s\";\n\n", stringsEscaper.Replace(message.ID), stringsEscaper.Replace(value))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ParseStringsDict parses an iOS .stringsdict file.
+//
+// Only the plural rule type with a single variable in the format key is supported.
+func ParseStringsDict(buf []byte, tag language.Tag) (*i18n.MessageFile, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(buf))
+	var entries map[string]interface{}
+	for entries == nil {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid .stringsdict file: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "dict" {
+			if entries, err = decodePlistDict(decoder); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	file := &i18n.MessageFile{Tag: tag, Format: "stringsdict"}
+	for _, id := range sortedKeys(entries) {
+		entry, ok := entries[id].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid entry %q: expected dict", id)
+		}
+		format, _ := entry["NSStringLocalizedFormatKey"].(string)
+		variables := formatVariable.FindAllStringSubmatchIndex(format, -1)
+		message := &i18n.Message{ID: id}
+		switch len(variables) {
+		case 0:
+			message.Other = fromPrintf(format, 0, 0)
+		case 1:
+			loc := variables[0]
+			name := format[loc[4]:loc[5]]
+			rule, ok := entry[name].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid entry %q: missing variable %q", id, name)
+			}
+			prefix, suffix := format[:loc[0]], format[loc[1]:]
+			// The variable takes the plural count, which the placeholders of the forms refer to,
+			// e.g. %d in the form "%d files" of "%@ has %#@count@".
+			count := printfArguments(prefix) + 1
+			if loc[2] >= 0 {
+				count, _ = strconv.Atoi(format[loc[2]:loc[3]])
+			}
+			prefix, suffix = fromPrintf(prefix, 0, count), fromPrintf(suffix, count, count)
+			for _, category := range pluralCategories {
+				if form, ok := rule[category].(string); ok {
+					setPluralForm(message, category, prefix+fromPrintf(form, count-1, count)+suffix)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid entry %q: multiple variables are not supported", id)
+		}
+		file.Messages = append(file.Messages, message)
+	}
+	return file, nil
+}
+
+func decodePlistDict(decoder *xml.Decoder) (map[string]interface{}, error) {
+	dict := map[string]interface{}{}
+	var key string
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "key" {
+				if err := decoder.DecodeElement(&key, &t); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			var value interface{}
+			switch t.Name.Local {
+			case "dict":
+				value, err = decodePlistDict(decoder)
+			case "string", "integer", "real":
+				var s string
+				err = decoder.DecodeElement(&s, &t)
+				value = s
+			default:
+				err = decoder.Skip()
+			}
+			if err != nil {
+				return nil, err
+			}
+			dict[key] = value
+		case xml.EndElement:
+			return dict, nil
+		}
+	}
+}
+
+// WriteStringsDict writes the plural messages as an iOS .stringsdict file.
+//
+// Messages without plural forms are skipped, use WriteStrings to write them.
+func WriteStringsDict(w io.Writer, file *i18n.MessageFile) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	for _, message := range file.Messages {
+		if !isPlural(message) {
+			continue
+		}
+		forms := pluralForms(message)
+		indexes := printfIndexes(formTexts(forms), true)
+		format := "%#@count@"
+		if count := indexes[pluralCountParam]; count != 1 {
+			format = fmt.Sprintf("%%%d$#@count@", count)
+		}
+		fmt.Fprintf(&b, "\t<key>%s</key>\n\t<dict>\n", xmlTextEscaper.Replace(message.ID))
+		fmt.Fprintf(&b, "\t\t<key>NSStringLocalizedFormatKey</key>\n\t\t<string>%s</string>\n", format)
+		b.WriteString("\t\t<key>count</key>\n\t\t<dict>\n")
+		b.WriteString("\t\t\t<key>NSStringFormatSpecTypeKey</key>\n\t\t\t<string>NSStringPluralRuleType</string>\n")
+		b.WriteString("\t\t\t<key>NSStringFormatValueTypeKey</key>\n\t\t\t<string>d</string>\n")
+		for _, category := range pluralCategories {
+			if form, ok := forms[category]; ok {
+				value := toPrintf(form, indexes, iosPlaceholder(true))
+				fmt.Fprintf(&b, "\t\t\t<key>%s</key>\n\t\t\t<string>%s</string>\n", category, xmlTextEscaper.Replace(value))
+			}
+		}
+		b.WriteString("\t\t</dict>\n\t</dict>\n")
+	}
+	b.WriteString("</dict>\n</plist>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
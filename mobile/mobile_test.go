@@ -0,0 +1,315 @@
+package mobile_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/ahmadfaizk/i18n/mobile"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func findMessage(t *testing.T, file *goi18n.MessageFile, id string) *goi18n.Message {
+	t.Helper()
+	for _, message := range file.Messages {
+		if message.ID == id {
+			return message
+		}
+	}
+	t.Fatalf("message %q not found", id)
+	return nil
+}
+
+func TestLanguageFromPath(t *testing.T) {
+	testCases := []struct {
+		path string
+		tag  language.Tag
+	}{
+		{path: "lib/l10n/app_id.arb", tag: language.Indonesian},
+		{path: "lib/l10n/app_pt_BR.arb", tag: language.BrazilianPortuguese},
+		{path: "lib/l10n/app.arb", tag: language.English},
+		{path: "res/values/strings.xml", tag: language.English},
+		{path: "res/values-id/strings.xml", tag: language.Indonesian},
+		{path: "res/values-pt-rBR/strings.xml", tag: language.BrazilianPortuguese},
+		{path: "res/values-b+sr+Latn/strings.xml", tag: language.MustParse("sr-Latn")},
+		{path: "id.lproj/Localizable.strings", tag: language.Indonesian},
+		{path: "Base.lproj/Localizable.strings", tag: language.English},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.tag, mobile.LanguageFromPath(tc.path, language.English))
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	fsys := os.DirFS("testdata")
+
+	t.Run("arb", func(t *testing.T) {
+		file, err := mobile.LoadFile(fsys, "app_id.arb", language.English)
+		require.NoError(t, err)
+		assert.Equal(t, language.Indonesian, file.Tag)
+
+		hello := findMessage(t, file, "hello")
+		assert.Equal(t, "Halo, {{.name}}!", hello.Other)
+		assert.Equal(t, "Greeting with the user name", hello.Description)
+
+		apple := findMessage(t, file, "apple")
+		assert.Equal(t, "Tidak ada apel", apple.Zero)
+		assert.Equal(t, "{{.PluralCount}} apel", apple.Other)
+
+		assert.Equal(t, "Gunakan {name} untuk nama", findMessage(t, file, "quoted").Other)
+	})
+
+	t.Run("android", func(t *testing.T) {
+		file, err := mobile.LoadFile(fsys, "values-id/strings.xml", language.English)
+		require.NoError(t, err)
+		assert.Equal(t, language.Indonesian, file.Tag)
+		assert.Equal(t, "Halo, {{.arg1}}!", findMessage(t, file, "hello").Other)
+		assert.Equal(t, "Halo {{.arg1}}! Kamu berumur {{.arg2}} tahun.", findMessage(t, file, "hello_age").Other)
+		assert.Equal(t, "Jangan 'lupa' & ingat", findMessage(t, file, "quote").Other)
+
+		apple := findMessage(t, file, "apple")
+		assert.Equal(t, "{{.PluralCount}} apel", apple.One)
+		assert.Equal(t, "{{.PluralCount}} apel", apple.Other)
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		file, err := mobile.LoadFile(fsys, "id.lproj/Localizable.strings", language.English)
+		require.NoError(t, err)
+		assert.Equal(t, language.Indonesian, file.Tag)
+
+		hello := findMessage(t, file, "hello")
+		assert.Equal(t, "Halo, {{.arg1}}!", hello.Other)
+		assert.Equal(t, "Greeting with the user name", hello.Description)
+		assert.Equal(t, "Greeting with name and age", findMessage(t, file, "hello_age").Description)
+		assert.Equal(t, "Baris \"satu\"\nBaris dua — selesai", findMessage(t, file, "escaped").Other)
+	})
+
+	t.Run("stringsdict", func(t *testing.T) {
+		file, err := mobile.LoadFile(fsys, "id.lproj/Localizable.stringsdict", language.English)
+		require.NoError(t, err)
+		apple := findMessage(t, file, "apple")
+		assert.Equal(t, "Kamu punya {{.PluralCount}} apel", apple.One)
+		assert.Equal(t, "Kamu punya {{.PluralCount}} apel", apple.Other)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := mobile.LoadFile(fsys, "app_id.json", language.English)
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteAndParse(t *testing.T) {
+	file := &goi18n.MessageFile{
+		Tag: language.Indonesian,
+		Messages: []*goi18n.Message{
+			{ID: "hello", Description: "Greeting", Other: "Halo, {{.name}}! 100% 'aman'"},
+			{ID: "apple", One: "{{.PluralCount}} apel milik {{.name}}", Other: "{{.PluralCount}} apel milik {{.name}}"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		write    func(*bytes.Buffer, *goi18n.MessageFile) error
+		parse    func([]byte, language.Tag) (*goi18n.MessageFile, error)
+		hello    string
+		apple    string
+		contains []string
+	}{
+		{
+			name:     "arb",
+			write:    func(b *bytes.Buffer, f *goi18n.MessageFile) error { return mobile.WriteARB(b, f) },
+			parse:    mobile.ParseARB,
+			hello:    "Halo, {{.name}}! 100% 'aman'",
+			apple:    "{{.PluralCount}} apel milik {{.name}}",
+			contains: []string{`"@@locale": "id"`, `"hello": "Halo, {name}! 100% ''aman''"`, `{count, plural, one{{count} apel milik {name}}`},
+		},
+		{
+			name:     "android",
+			write:    func(b *bytes.Buffer, f *goi18n.MessageFile) error { return mobile.WriteAndroidStrings(b, f) },
+			parse:    mobile.ParseAndroidStrings,
+			hello:    "Halo, {{.arg1}}! 100% 'aman'",
+			apple:    "{{.PluralCount}} apel milik {{.arg2}}",
+			contains: []string{`<string name="hello">Halo, %1$s! 100%% \'aman\'</string>`, `<item quantity="one">%1$d apel milik %2$s</item>`},
+		},
+		{
+			name:     "strings",
+			write:    func(b *bytes.Buffer, f *goi18n.MessageFile) error { return mobile.WriteStrings(b, f) },
+			parse:    mobile.ParseStrings,
+			hello:    "Halo, {{.arg1}}! 100% 'aman'",
+			contains: []string{"/* Greeting */", `"hello" = "Halo, %1$@! 100%% 'aman'";`},
+		},
+		{
+			name:     "stringsdict",
+			write:    func(b *bytes.Buffer, f *goi18n.MessageFile) error { return mobile.WriteStringsDict(b, f) },
+			parse:    mobile.ParseStringsDict,
+			apple:    "{{.PluralCount}} apel milik {{.arg2}}",
+			contains: []string{"<string>%#@count@</string>", "<string>%1$d apel milik %2$@</string>"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tc.write(&buf, file))
+			for _, s := range tc.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+
+			parsed, err := tc.parse(buf.Bytes(), language.Indonesian)
+			require.NoError(t, err)
+			if tc.hello != "" {
+				assert.Equal(t, tc.hello, findMessage(t, parsed, "hello").Other)
+			}
+			if tc.apple != "" {
+				apple := findMessage(t, parsed, "apple")
+				assert.Equal(t, tc.apple, apple.One)
+				assert.Equal(t, tc.apple, apple.Other)
+			}
+		})
+	}
+}
+
+func TestParsePluralCount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		parse    func([]byte, language.Tag) (*goi18n.MessageFile, error)
+		write    func(*bytes.Buffer, *goi18n.MessageFile) error
+		expected string
+		contains []string
+	}{
+		{
+			name: "android",
+			content: `<resources>
+    <plurals name="files">
+        <item quantity="one">%1$s punya %2$d berkas</item>
+        <item quantity="other">%1$s punya %2$d berkas</item>
+    </plurals>
+</resources>`,
+			parse:    mobile.ParseAndroidStrings,
+			write:    func(b *bytes.Buffer, f *goi18n.MessageFile) error { return mobile.WriteAndroidStrings(b, f) },
+			expected: "{{.arg1}} punya {{.PluralCount}} berkas",
+			contains: []string{`<item quantity="other">%1$s punya %2$d berkas</item>`},
+		},
+		{
+			name: "android without position",
+			content: `<resources>
+    <plurals name="files">
+        <item quantity="one">%s punya %d berkas</item>
+        <item quantity="other">%s punya %d berkas</item>
+    </plurals>
+</resources>`,
+			parse:    mobile.ParseAndroidStrings,
+			write:    func(b *bytes.Buffer, f *goi18n.MessageFile) error { return mobile.WriteAndroidStrings(b, f) },
+			expected: "{{.arg1}} punya {{.PluralCount}} berkas",
+			contains: []string{`<item quantity="other">%1$s punya %2$d berkas</item>`},
+		},
+		{
+			name: "stringsdict",
+			content: `<plist version="1.0">
+<dict>
+	<key>files</key>
+	<dict>
+		<key>NSStringLocalizedFormatKey</key>
+		<string>%@ punya %#@count@</string>
+		<key>count</key>
+		<dict>
+			<key>NSStringFormatSpecTypeKey</key>
+			<string>NSStringPluralRuleType</string>
+			<key>NSStringFormatValueTypeKey</key>
+			<string>d</string>
+			<key>one</key>
+			<string>%d berkas</string>
+			<key>other</key>
+			<string>%d berkas</string>
+		</dict>
+	</dict>
+</dict>
+</plist>`,
+			parse:    mobile.ParseStringsDict,
+			write:    func(b *bytes.Buffer, f *goi18n.MessageFile) error { return mobile.WriteStringsDict(b, f) },
+			expected: "{{.arg1}} punya {{.PluralCount}} berkas",
+			contains: []string{"<string>%2$#@count@</string>", "<string>%1$@ punya %2$d berkas</string>"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			file, err := tc.parse([]byte(tc.content), language.Indonesian)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, findMessage(t, file, "files").Other)
+
+			var buf bytes.Buffer
+			require.NoError(t, tc.write(&buf, file))
+			for _, s := range tc.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+			parsed, err := tc.parse(buf.Bytes(), language.Indonesian)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, findMessage(t, parsed, "files").Other)
+		})
+	}
+}
+
+func TestWriteAndroidStringsEscapesNames(t *testing.T) {
+	file := &goi18n.MessageFile{
+		Tag: language.Indonesian,
+		Messages: []*goi18n.Message{
+			{ID: `say "hi" & <bye>`, Other: "Halo"},
+			{ID: "café", One: "{{.PluralCount}} kopi", Other: "{{.PluralCount}} kopi"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, mobile.WriteAndroidStrings(&buf, file))
+	assert.Contains(t, buf.String(), `<string name="say &#34;hi&#34; &amp; &lt;bye&gt;">Halo</string>`)
+	assert.Contains(t, buf.String(), `<plurals name="café">`)
+
+	parsed, err := mobile.ParseAndroidStrings(buf.Bytes(), language.Indonesian)
+	require.NoError(t, err)
+	assert.Equal(t, "Halo", findMessage(t, parsed, `say "hi" & <bye>`).Other)
+	assert.Equal(t, "{{.PluralCount}} kopi", findMessage(t, parsed, "café").Other)
+}
+
+func TestParseARBInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		buf      string
+		expected string
+	}{
+		{
+			name:     "empty id",
+			buf:      `{"": "x"}`,
+			expected: "empty message id",
+		},
+		{
+			name:     "invalid locale",
+			buf:      `{"@@locale": 1, "hello": "Halo"}`,
+			expected: "invalid @@locale: json: cannot unmarshal number into Go value of type string",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := mobile.ParseARB([]byte(tc.buf), language.English)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestInitWithMobileFile(t *testing.T) {
+	file, err := mobile.LoadFile(os.DirFS("testdata"), "values-id/strings.xml", language.English)
+	require.NoError(t, err)
+
+	err = i18n.Init(language.English, i18n.WithMessageFile(file))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Halo, John!", i18n.T("hello", i18n.Lang("id"), i18n.Param("arg1", "John")))
+	assert.Equal(t, "3 apel", i18n.T("apple", i18n.Lang("id"), i18n.Plural(3)))
+}
@@ -0,0 +1,390 @@
+package mobile
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+const pluralCountParam = "PluralCount"
+
+var (
+	templateParamRegexp = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+	printfRegexp        = regexp.MustCompile(`%(?:(\d+)\$)?[-#+0,(]*\d*(?:\.\d+)?(?:hh|h|ll|l|q|z|t|j)?([@dDiuUxXoOfFeEgGcCsSaAb%])`)
+	argParamRegexp      = regexp.MustCompile(`^arg(\d+)$`)
+)
+
+// pluralForms returns the plural forms of the message keyed by CLDR plural category.
+func pluralForms(m *i18n.Message) map[string]string {
+	forms := map[string]string{}
+	for category, form := range map[string]string{
+		"zero": m.Zero, "one": m.One, "two": m.Two, "few": m.Few, "many": m.Many, "other": m.Other,
+	} {
+		if form != "" {
+			forms[category] = form
+		}
+	}
+	return forms
+}
+
+var pluralCategories = []string{"zero", "one", "two", "few", "many", "other"}
+
+// formTexts returns the texts of the plural forms in the order of the plural categories.
+func formTexts(forms map[string]string) []string {
+	var texts []string
+	for _, category := range pluralCategories {
+		if form, ok := forms[category]; ok {
+			texts = append(texts, form)
+		}
+	}
+	return texts
+}
+
+func isPlural(m *i18n.Message) bool {
+	return m.Zero != "" || m.One != "" || m.Two != "" || m.Few != "" || m.Many != ""
+}
+
+func setPluralForm(m *i18n.Message, category, form string) bool {
+	switch category {
+	case "zero":
+		m.Zero = form
+	case "one":
+		m.One = form
+	case "two":
+		m.Two = form
+	case "few":
+		m.Few = form
+	case "many":
+		m.Many = form
+	case "other":
+		m.Other = form
+	default:
+		return false
+	}
+	return true
+}
+
+func paramName(index, count int) string {
+	if index == count {
+		return pluralCountParam
+	}
+	return "arg" + strconv.Itoa(index)
+}
+
+// fromPrintf converts printf style placeholders to Go template placeholders.
+//
+// The placeholders without a position take the arguments after the first one. The argument at the count
+// position is the plural count, or none if it is 0.
+func fromPrintf(s string, first, count int) string {
+	next := first
+	return printfRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		groups := printfRegexp.FindStringSubmatch(match)
+		if groups[2] == "%" {
+			return "%"
+		}
+		index := 0
+		if groups[1] != "" {
+			index, _ = strconv.Atoi(groups[1])
+		} else {
+			next++
+			index = next
+		}
+		return "{{." + paramName(index, count) + "}}"
+	})
+}
+
+// printfArguments returns the number of arguments taken by the printf style placeholders without a position.
+func printfArguments(s string) int {
+	n := 0
+	for _, groups := range printfRegexp.FindAllStringSubmatch(s, -1) {
+		if groups[1] == "" && groups[2] != "%" {
+			n++
+		}
+	}
+	return n
+}
+
+// integerArgument returns the position of the first argument with an integer conversion, e.g. %d or %1$d,
+// or 0 if there is none.
+func integerArgument(s string) int {
+	next := 0
+	for _, groups := range printfRegexp.FindAllStringSubmatch(s, -1) {
+		if groups[2] == "%" {
+			continue
+		}
+		index := 0
+		if groups[1] != "" {
+			index, _ = strconv.Atoi(groups[1])
+		} else {
+			next++
+			index = next
+		}
+		if groups[2] == "d" || groups[2] == "i" {
+			return index
+		}
+	}
+	return 0
+}
+
+// printfIndexes returns the printf positions of the params of the texts.
+//
+// The argN params keep their position. In plural messages the plural count takes the first free position,
+// even if the texts do not use it. The other params take the next free positions in order of appearance.
+func printfIndexes(texts []string, plural bool) map[string]int {
+	indexes := map[string]int{}
+	used := map[int]bool{}
+	for _, s := range texts {
+		for _, groups := range templateParamRegexp.FindAllStringSubmatch(s, -1) {
+			if matches := argParamRegexp.FindStringSubmatch(groups[1]); matches != nil {
+				indexes[groups[1]], _ = strconv.Atoi(matches[1])
+				used[indexes[groups[1]]] = true
+			}
+		}
+	}
+	next := 1
+	assign := func(name string) {
+		for used[next] {
+			next++
+		}
+		indexes[name] = next
+		used[next] = true
+	}
+	if plural {
+		assign(pluralCountParam)
+	}
+	for _, s := range texts {
+		for _, groups := range templateParamRegexp.FindAllStringSubmatch(s, -1) {
+			if _, ok := indexes[groups[1]]; !ok {
+				assign(groups[1])
+			}
+		}
+	}
+	return indexes
+}
+
+// toPrintf converts Go template placeholders to printf style placeholders with the positions of printfIndexes.
+//
+// The placeholder function receives the parameter name and its position.
+func toPrintf(s string, indexes map[string]int, placeholder func(name string, index int) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range templateParamRegexp.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(strings.ReplaceAll(s[last:loc[0]], "%", "%%"))
+		name := s[loc[2]:loc[3]]
+		b.WriteString(placeholder(name, indexes[name]))
+		last = loc[1]
+	}
+	b.WriteString(strings.ReplaceAll(s[last:], "%", "%%"))
+	return b.String()
+}
+
+// fromICU converts an ICU message format string to Go template plural forms.
+//
+// Simple arguments such as "{name}" become "{{.name}}". A plural argument such as
+// "{count, plural, one{# item} other{# items}}" becomes one form per category where
+// "#" and "{count}" are replaced by "{{.PluralCount}}". Exact matches "=0", "=1" and "=2"
+// are mapped to the zero, one and two categories. Only one plural argument is supported.
+func fromICU(s string) (map[string]string, error) {
+	var (
+		b      strings.Builder
+		prefix string
+		forms  map[string]string
+	)
+	err := convertICU(s, "", &b, func(name, options string) error {
+		if forms != nil {
+			return fmt.Errorf("multiple plural arguments are not supported: %q", s)
+		}
+		var err error
+		forms, err = parseICUPlural(name, options)
+		prefix = b.String()
+		b.Reset()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if forms == nil {
+		return map[string]string{"other": b.String()}, nil
+	}
+	for category, form := range forms {
+		forms[category] = prefix + form + b.String()
+	}
+	return forms, nil
+}
+
+func parseICUPlural(name, options string) (map[string]string, error) {
+	forms := map[string]string{}
+	exact := map[string]string{}
+	for i := 0; i < len(options); {
+		if options[i] == ' ' || options[i] == '\t' || options[i] == '\n' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(options) && options[i] != '{' && options[i] != ' ' {
+			i++
+		}
+		selector := options[start:i]
+		for i < len(options) && options[i] == ' ' {
+			i++
+		}
+		if strings.HasPrefix(selector, "offset:") {
+			continue
+		}
+		if i >= len(options) || options[i] != '{' {
+			return nil, fmt.Errorf("missing message for plural selector %q", selector)
+		}
+		end, err := matchBrace(options, i)
+		if err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		if err := convertICU(options[i+1:end], name, &b, func(string, string) error {
+			return fmt.Errorf("nested plural arguments are not supported")
+		}); err != nil {
+			return nil, err
+		}
+		switch selector {
+		case "=0":
+			exact["zero"] = b.String()
+		case "=1":
+			exact["one"] = b.String()
+		case "=2":
+			exact["two"] = b.String()
+		default:
+			forms[selector] = b.String()
+		}
+		i = end + 1
+	}
+	for category, form := range exact {
+		if _, ok := forms[category]; !ok {
+			forms[category] = form
+		}
+	}
+	if _, ok := forms["other"]; !ok {
+		return nil, fmt.Errorf("plural argument %q has no other form", name)
+	}
+	return forms, nil
+}
+
+func convertICU(s, pluralVar string, b *strings.Builder, onPlural func(name, options string) error) error {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+			} else if i+1 < len(s) && strings.IndexByte("{}#", s[i+1]) >= 0 {
+				end := strings.IndexByte(s[i+1:], '\'')
+				if end < 0 {
+					end = len(s) - i - 1
+				}
+				b.WriteString(s[i+1 : i+1+end])
+				i += end + 1
+			} else {
+				b.WriteByte(c)
+			}
+		case c == '#' && pluralVar != "":
+			b.WriteString("{{." + pluralCountParam + "}}")
+		case c == '{':
+			end, err := matchBrace(s, i)
+			if err != nil {
+				return err
+			}
+			parts := strings.SplitN(s[i+1:end], ",", 3)
+			name := strings.TrimSpace(parts[0])
+			if len(parts) == 3 && strings.TrimSpace(parts[1]) == "plural" {
+				if err := onPlural(name, parts[2]); err != nil {
+					return err
+				}
+			} else if len(parts) == 3 && strings.TrimSpace(parts[1]) == "select" {
+				return fmt.Errorf("select arguments are not supported: %q", s[i:end+1])
+			} else if name == pluralVar {
+				b.WriteString("{{." + pluralCountParam + "}}")
+			} else {
+				b.WriteString("{{." + name + "}}")
+			}
+			i = end
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return nil
+}
+
+func matchBrace(s string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unbalanced braces in %q", s)
+}
+
+// toICU converts the message to an ICU message format string.
+//
+// Plural messages use "count" as the plural argument name.
+func toICU(m *i18n.Message) string {
+	if !isPlural(m) {
+		return templateToICU(m.Other, "")
+	}
+	forms := pluralForms(m)
+	var b strings.Builder
+	b.WriteString("{count, plural,")
+	for _, category := range pluralCategories {
+		if form, ok := forms[category]; ok {
+			b.WriteString(" " + category + "{" + templateToICU(form, "count") + "}")
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func templateToICU(s, pluralVar string) string {
+	var b strings.Builder
+	last := 0
+	escape := strings.NewReplacer("'", "''", "{", "'{'", "}", "'}'")
+	for _, loc := range templateParamRegexp.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(escape.Replace(s[last:loc[0]]))
+		name := s[loc[2]:loc[3]]
+		if name == pluralCountParam && pluralVar != "" {
+			name = pluralVar
+		}
+		b.WriteString("{" + name + "}")
+		last = loc[1]
+	}
+	b.WriteString(escape.Replace(s[last:]))
+	return b.String()
+}
+
+// icuParams returns the argument names used by the message in order of appearance.
+func icuParams(m *i18n.Message) []string {
+	var params []string
+	seen := map[string]bool{}
+	if isPlural(m) {
+		params = append(params, "count")
+		seen["count"] = true
+	}
+	forms := pluralForms(m)
+	for _, category := range pluralCategories {
+		for _, groups := range templateParamRegexp.FindAllStringSubmatch(forms[category], -1) {
+			name := groups[1]
+			if name == pluralCountParam || seen[name] {
+				continue
+			}
+			seen[name] = true
+			params = append(params, name)
+		}
+	}
+	return params
+}
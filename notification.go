@@ -0,0 +1,178 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// NotificationSubjectFile is the file name of the subject template of a notification.
+	NotificationSubjectFile = "subject.txt"
+	// NotificationHTMLFile is the file name of the html/template body of a notification.
+	NotificationHTMLFile = "body.html"
+	// NotificationTextFile is the file name of the text/template body of a notification.
+	NotificationTextFile = "body.txt"
+)
+
+// ErrNotificationNotFound is returned when a notification has no template set for the languages of the context.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// Notification is a rendered notification, e.g. a transactional email.
+type Notification struct {
+	Name     string       `json:"name"`
+	Language language.Tag `json:"language"`
+	Subject  string       `json:"subject"`
+	HTML     string       `json:"html,omitempty"`
+	Text     string       `json:"text,omitempty"`
+}
+
+// String returns the notification in a stable format for snapshot tests.
+func (n *Notification) String() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Name: %s\nLanguage: %s\nSubject: %s\n", n.Name, n.Language, n.Subject)
+	if n.Text != "" {
+		fmt.Fprintf(&buf, "\n--- text ---\n%s\n", strings.TrimRight(n.Text, "\n"))
+	}
+	if n.HTML != "" {
+		fmt.Fprintf(&buf, "\n--- html ---\n%s\n", strings.TrimRight(n.HTML, "\n"))
+	}
+	return buf.String()
+}
+
+// RenderNotification renders the notification in the language of the context.
+//
+// The template sets are read from the name/language directories of the file system, e.g. welcome/en and welcome/id.
+// A set has the subject.txt template and the body.html or body.txt templates. The language of the set is matched
+// along the languages GetCtx uses: the Lang option, the language of the context and the default language.
+//
+// The templates get the params as data and the functions of FuncMap bound to the language of the set,
+// so they can use the strings of the catalog.
+//
+// Example:
+//
+//	//go:embed notifications
+//	var notifications embed.FS
+//
+//	n, err := i18n.RenderNotification(ctx, notifications, "notifications/welcome", i18n.Param("name", user.Name))
+//	if err != nil {
+//		return err
+//	}
+//	err = mailer.Send(user.Email, n.Subject, n.HTML, n.Text)
+func RenderNotification(ctx context.Context, fsys fs.FS, name string, opts ...any) (*Notification, error) {
+	mu.RLock()
+	b, fallback, extract := bundle, defaultLanguage, extractLanguageFunc
+	mu.RUnlock()
+	if b == nil {
+		panic(ErrI18nNotInitialized)
+	}
+
+	cfg := newLocalizeConfig(opts...)
+	tag, dir, err := notificationLanguage(fsys, name, requestedLanguages(ctx, cfg.language, extract, fallback))
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := funcMap(ctx, tag.String())
+	notification := &Notification{Name: path.Base(name), Language: tag}
+	subject, err := renderTextTemplate(fsys, path.Join(dir, NotificationSubjectFile), funcs, cfg.params)
+	if err != nil {
+		return nil, err
+	}
+	notification.Subject = strings.TrimSpace(subject)
+	if notification.HTML, err = renderHTMLTemplate(fsys, path.Join(dir, NotificationHTMLFile), funcs, cfg.params); err != nil {
+		return nil, err
+	}
+	if notification.Text, err = renderTextTemplate(fsys, path.Join(dir, NotificationTextFile), funcs, cfg.params); err != nil {
+		return nil, err
+	}
+	if notification.HTML == "" && notification.Text == "" {
+		return nil, fmt.Errorf("%w: %s has no body", ErrNotificationNotFound, dir)
+	}
+	return notification, nil
+}
+
+// notificationLanguage matches the languages with the template sets of the notification
+// and returns the language and the directory of the set.
+func notificationLanguage(fsys fs.FS, name string, languages []string) (language.Tag, string, error) {
+	entries, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		return language.Und, "", fmt.Errorf("%w: %s", ErrNotificationNotFound, name)
+	}
+	var (
+		supported []language.Tag
+		dirs      []string
+	)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if tag, err := language.Parse(entry.Name()); err == nil {
+			supported = append(supported, tag)
+			dirs = append(dirs, path.Join(name, entry.Name()))
+		}
+	}
+
+	var desired []language.Tag
+	for _, lang := range languages {
+		tags, _, err := language.ParseAcceptLanguage(lang)
+		if err == nil {
+			desired = append(desired, tags...)
+		}
+	}
+	if len(supported) > 0 {
+		_, index, confidence := language.NewMatcher(supported).Match(desired...)
+		if confidence != language.No {
+			return supported[index], dirs[index], nil
+		}
+	}
+	return language.Und, "", fmt.Errorf("%w: %s for %s", ErrNotificationNotFound, name, strings.Join(languages, ", "))
+}
+
+// renderTextTemplate renders the text/template file, or returns an empty string if the file does not exist.
+func renderTextTemplate(fsys fs.FS, name string, funcs map[string]any, data any) (string, error) {
+	src, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := texttemplate.New(path.Base(name)).Funcs(funcs).Parse(string(src))
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderHTMLTemplate renders the html/template file, or returns an empty string if the file does not exist.
+func renderHTMLTemplate(fsys fs.FS, name string, funcs map[string]any, data any) (string, error) {
+	src, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := htmltemplate.New(path.Base(name)).Funcs(funcs).Parse(string(src))
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,76 @@
+package i18n_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderNotification(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	fsys := os.DirFS("testdata/notifications")
+	params := []any{
+		i18n.Param("name", "<John>"),
+		i18n.Param("url", "/terms"),
+		i18n.Param("count", 2),
+	}
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		opts     []any
+		expected string
+	}{
+		{
+			name: "default language",
+			ctx:  context.Background(),
+			expected: "Name: welcome\nLanguage: en\nSubject: Hello, <John>!\n" +
+				"\n--- text ---\nHello, <John>!\n\n2 apples\n" +
+				"\n--- html ---\n<p>Hello, &lt;John&gt;!</p>\n<p>Read the <a href=\"/terms\">terms</a>, &lt;John&gt;</p>\n<p lang=\"en\">2 apples</p>\n",
+		},
+		{
+			name: "language of the context",
+			ctx:  i18n.NewContextWithLanguage(context.Background(), "id-ID"),
+			expected: "Name: welcome\nLanguage: id\nSubject: Halo <John>\n" +
+				"\n--- text ---\nHalo <John>\n\n2 apel di Keranjang\n",
+		},
+		{
+			name: "language option",
+			ctx:  i18n.NewContextWithLanguage(context.Background(), "id"),
+			opts: []any{i18n.Lang("en")},
+			expected: "Name: welcome\nLanguage: en\nSubject: Hello, <John>!\n" +
+				"\n--- text ---\nHello, <John>!\n\n2 apples\n" +
+				"\n--- html ---\n<p>Hello, &lt;John&gt;!</p>\n<p>Read the <a href=\"/terms\">terms</a>, &lt;John&gt;</p>\n<p lang=\"en\">2 apples</p>\n",
+		},
+		{
+			name: "fallback to the default language",
+			ctx:  i18n.NewContextWithLanguage(context.Background(), "fr"),
+			expected: "Name: welcome\nLanguage: en\nSubject: Hello, <John>!\n" +
+				"\n--- text ---\nHello, <John>!\n\n2 apples\n" +
+				"\n--- html ---\n<p>Hello, &lt;John&gt;!</p>\n<p>Read the <a href=\"/terms\">terms</a>, &lt;John&gt;</p>\n<p lang=\"en\">2 apples</p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := i18n.RenderNotification(tc.ctx, fsys, "welcome", append(params, tc.opts...)...)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, n.String())
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := i18n.RenderNotification(context.Background(), fsys, "goodbye")
+		assert.ErrorIs(t, err, i18n.ErrNotificationNotFound)
+	})
+}
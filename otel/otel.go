@@ -0,0 +1,110 @@
+// Package i18notel provides an OpenTelemetry hook that traces the localization of the messages.
+//
+// Every message localized by i18n.GetCtx is recorded as a span, or as an event of the current span,
+// with the message id, the requested and resolved languages and the fallback and missing status.
+//
+// Example:
+//
+//	err := i18n.Init(language.English,
+//		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+//		i18n.WithTranslationFile("locales/en.yaml", "locales/id.yaml"),
+//		i18n.WithHook(i18notel.NewHook()),
+//	)
+package i18notel
+
+import (
+	"context"
+
+	"github.com/ahmadfaizk/i18n"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ScopeName is the instrumentation scope name of the tracer.
+	ScopeName = "github.com/ahmadfaizk/i18n/otel"
+	// SpanName is the name of the spans and the events.
+	SpanName = "i18n.Localize"
+
+	// MessageIDKey is the attribute key of the message id.
+	MessageIDKey = attribute.Key("i18n.message.id")
+	// RequestedLanguagesKey is the attribute key of the requested languages in order of preference.
+	RequestedLanguagesKey = attribute.Key("i18n.language.requested")
+	// ResolvedLanguageKey is the attribute key of the language of the served message.
+	ResolvedLanguageKey = attribute.Key("i18n.language.resolved")
+	// FallbackKey is the attribute key that reports whether the message is served in another language
+	// than the first requested language.
+	FallbackKey = attribute.Key("i18n.fallback")
+	// MissingKey is the attribute key that reports whether the message is not found.
+	MissingKey = attribute.Key("i18n.missing")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	events         bool
+}
+
+// Option is the option for the hook.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. The global tracer provider is used by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithEvents records the localization as an event of the span of the context instead of a new span.
+//
+// It is cheaper for pages that render many messages, but it does not measure the time.
+func WithEvents() Option {
+	return func(c *config) {
+		c.events = true
+	}
+}
+
+type hook struct {
+	tracer trace.Tracer
+	events bool
+}
+
+// NewHook returns an i18n.Hook that records the localization of the messages.
+func NewHook(opts ...Option) i18n.Hook {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tracerProvider == nil {
+		c.tracerProvider = otel.GetTracerProvider()
+	}
+	return &hook{
+		tracer: c.tracerProvider.Tracer(ScopeName),
+		events: c.events,
+	}
+}
+
+func (h *hook) BeforeLocalize(ctx context.Context, id string, languages []string) context.Context {
+	if h.events {
+		return ctx
+	}
+	ctx, _ = h.tracer.Start(ctx, SpanName, trace.WithAttributes(MessageIDKey.String(id)))
+	return ctx
+}
+
+func (h *hook) AfterLocalize(ctx context.Context, result i18n.LocalizeResult) {
+	span := trace.SpanFromContext(ctx)
+	attributes := []attribute.KeyValue{
+		MessageIDKey.String(result.ID),
+		RequestedLanguagesKey.StringSlice(result.Languages),
+		ResolvedLanguageKey.String(result.Language.String()),
+		FallbackKey.Bool(result.Fallback),
+		MissingKey.Bool(result.Missing),
+	}
+	if h.events {
+		span.AddEvent(SpanName, trace.WithAttributes(attributes...))
+		return
+	}
+	span.SetAttributes(attributes...)
+	span.End()
+}
@@ -0,0 +1,114 @@
+package i18notel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	i18notel "github.com/ahmadfaizk/i18n/otel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func initI18n(t *testing.T, hook i18n.Hook) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("../testdata/en.yaml", "../testdata/id.yaml"),
+		i18n.WithHook(hook),
+	)
+	require.NoError(t, err)
+}
+
+func TestHook(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	initI18n(t, i18notel.NewHook(i18notel.WithTracerProvider(provider)))
+
+	testCases := []struct {
+		name               string
+		language           string
+		messageID          string
+		expectedAttributes []attribute.KeyValue
+	}{
+		{
+			name:      "hit",
+			language:  "id",
+			messageID: "hello_world",
+			expectedAttributes: []attribute.KeyValue{
+				i18notel.MessageIDKey.String("hello_world"),
+				i18notel.RequestedLanguagesKey.StringSlice([]string{"id", "en"}),
+				i18notel.ResolvedLanguageKey.String("id"),
+				i18notel.FallbackKey.Bool(false),
+				i18notel.MissingKey.Bool(false),
+			},
+		},
+		{
+			name:      "fallback",
+			language:  "id",
+			messageID: "only_in_en",
+			expectedAttributes: []attribute.KeyValue{
+				i18notel.MessageIDKey.String("only_in_en"),
+				i18notel.RequestedLanguagesKey.StringSlice([]string{"id", "en"}),
+				i18notel.ResolvedLanguageKey.String("en"),
+				i18notel.FallbackKey.Bool(true),
+				i18notel.MissingKey.Bool(false),
+			},
+		},
+		{
+			name:      "missing",
+			messageID: "not_found",
+			expectedAttributes: []attribute.KeyValue{
+				i18notel.MessageIDKey.String("not_found"),
+				i18notel.RequestedLanguagesKey.StringSlice([]string{"en"}),
+				i18notel.ResolvedLanguageKey.String("en"),
+				i18notel.FallbackKey.Bool(false),
+				i18notel.MissingKey.Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter.Reset()
+			ctx := context.Background()
+			if tc.language != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.language)
+			}
+			i18n.TCtx(ctx, tc.messageID)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, i18notel.SpanName, spans[0].Name)
+			assert.Equal(t, i18notel.ScopeName, spans[0].InstrumentationScope.Name)
+			assert.ElementsMatch(t, tc.expectedAttributes, spans[0].Attributes)
+		})
+	}
+}
+
+func TestHookWithEvents(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	initI18n(t, i18notel.NewHook(i18notel.WithTracerProvider(provider), i18notel.WithEvents()))
+
+	ctx, span := provider.Tracer("test").Start(i18n.NewContextWithLanguage(context.Background(), "id"), "page")
+	assert.Equal(t, "Halo, Dunia!", i18n.TCtx(ctx, "hello_world"))
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "page", spans[0].Name)
+	require.Len(t, spans[0].Events, 1)
+	assert.Equal(t, i18notel.SpanName, spans[0].Events[0].Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		i18notel.MessageIDKey.String("hello_world"),
+		i18notel.RequestedLanguagesKey.StringSlice([]string{"id", "en"}),
+		i18notel.ResolvedLanguageKey.String("id"),
+		i18notel.FallbackKey.Bool(false),
+		i18notel.MissingKey.Bool(false),
+	}, spans[0].Events[0].Attributes)
+}
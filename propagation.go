@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	// LanguageHeaderKey is the header that carries the language of the context in job payloads and message headers.
+	LanguageHeaderKey = "Accept-Language"
+	// TenantHeaderKey is the header that carries the tenant of the context in job payloads and message headers.
+	TenantHeaderKey = "X-Tenant"
+)
+
+// Carrier is a set of headers that carries the language, e.g. the headers of a queue message.
+//
+// http.Header and HeaderCarrier implement it.
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// HeaderCarrier adapts a map of headers to Carrier. The keys are matched case-insensitively.
+type HeaderCarrier map[string]string
+
+// Get returns the value of the key.
+func (c HeaderCarrier) Get(key string) string {
+	if value, ok := c[key]; ok {
+		return value
+	}
+	for k, value := range c {
+		if strings.EqualFold(k, key) {
+			return value
+		}
+	}
+	return ""
+}
+
+// Set sets the value of the key.
+func (c HeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// LanguageHeader returns the headers that carry the language and the tenant of the context.
+//
+// The language and the tenant are extracted as GetCtx does. The headers are empty when the context has neither.
+//
+// Example:
+//
+//	job := Job{Type: "welcome_email", UserID: user.ID, Headers: i18n.LanguageHeader(r.Context())}
+func LanguageHeader(ctx context.Context) map[string]string {
+	headers := make(map[string]string)
+	Inject(ctx, HeaderCarrier(headers))
+	return headers
+}
+
+// ContextFromHeaders returns a context with the language and the tenant of the headers set by LanguageHeader.
+//
+// The keys are matched case-insensitively, so it also accepts the headers of an HTTP request.
+//
+// Example:
+//
+//	func (w *Worker) Handle(job Job) error {
+//		ctx := i18n.ContextFromHeaders(context.Background(), job.Headers)
+//		return w.mailer.Send(job.UserID, i18n.TCtx(ctx, "welcome.subject"))
+//	}
+func ContextFromHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return Extract(ctx, HeaderCarrier(headers))
+}
+
+// Inject sets the language and the tenant of the context to the carrier.
+//
+// Example:
+//
+//	msg := &nats.Msg{Subject: "emails", Data: data, Header: nats.Header{}}
+//	i18n.Inject(ctx, http.Header(msg.Header))
+func Inject(ctx context.Context, carrier Carrier) {
+	mu.RLock()
+	extractTenant := extractTenantFunc
+	mu.RUnlock()
+	if extractTenant == nil {
+		extractTenant = defaultExtractTenantFunc
+	}
+
+	if lang := ExtractLanguage(ctx); lang != "" {
+		carrier.Set(LanguageHeaderKey, lang)
+	}
+	if tenant := extractTenant(ctx); tenant != "" {
+		carrier.Set(TenantHeaderKey, tenant)
+	}
+}
+
+// Extract returns a context with the language and the tenant of the carrier.
+//
+// The context is returned as is when the carrier has neither.
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	if lang := carrier.Get(LanguageHeaderKey); lang != "" {
+		ctx = NewContextWithLanguage(ctx, lang)
+	}
+	if tenant := carrier.Get(TenantHeaderKey); tenant != "" {
+		ctx = NewContextWithTenant(ctx, tenant)
+	}
+	return ctx
+}
+
+// Envelope wraps the payload of a job or a queue message with the headers that carry the language.
+//
+// It is marshaled as {"headers": {...}, "payload": ...}.
+//
+// Example:
+//
+//	buf, _ := json.Marshal(i18n.NewEnvelope(r.Context(), WelcomeEmail{UserID: user.ID}))
+//	_ = queue.Publish("emails", buf)
+//
+//	var envelope i18n.Envelope[WelcomeEmail]
+//	_ = json.Unmarshal(buf, &envelope)
+//	ctx := envelope.Context(context.Background())
+type Envelope[T any] struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload T                 `json:"payload"`
+}
+
+// NewEnvelope wraps the payload with the headers returned by LanguageHeader.
+func NewEnvelope[T any](ctx context.Context, payload T) Envelope[T] {
+	return Envelope[T]{Headers: LanguageHeader(ctx), Payload: payload}
+}
+
+// Context returns a context with the language and the tenant of the envelope.
+func (e Envelope[T]) Context(ctx context.Context) context.Context {
+	return ContextFromHeaders(ctx, e.Headers)
+}
@@ -0,0 +1,125 @@
+package i18n_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLanguageHeader(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected map[string]string
+	}{
+		{
+			name:     "empty context",
+			ctx:      context.Background(),
+			expected: map[string]string{},
+		},
+		{
+			name:     "language",
+			ctx:      i18n.NewContextWithLanguage(context.Background(), "id"),
+			expected: map[string]string{"Accept-Language": "id"},
+		},
+		{
+			name:     "language and tenant",
+			ctx:      i18n.NewContextWithTenant(i18n.NewContextWithLanguage(context.Background(), "id"), "acme"),
+			expected: map[string]string{"Accept-Language": "id", "X-Tenant": "acme"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, i18n.LanguageHeader(tc.ctx))
+		})
+	}
+}
+
+func TestContextFromHeaders(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		headers  map[string]string
+		expected string
+		tenant   string
+	}{
+		{
+			name:     "no headers",
+			expected: "Hello, World!",
+		},
+		{
+			name:     "language",
+			headers:  map[string]string{"Accept-Language": "id"},
+			expected: "Halo, Dunia!",
+		},
+		{
+			name:     "case-insensitive keys",
+			headers:  map[string]string{"accept-language": "id", "x-tenant": "acme"},
+			expected: "Halo, Dunia!",
+			tenant:   "acme",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := i18n.ContextFromHeaders(context.Background(), tc.headers)
+			assert.Equal(t, tc.expected, i18n.TCtx(ctx, "hello_world"))
+			assert.Equal(t, tc.tenant, i18n.GetTenant(ctx))
+		})
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+	header := http.Header{}
+	i18n.Inject(ctx, header)
+	assert.Equal(t, "id", header.Get("Accept-Language"))
+
+	ctx = i18n.Extract(context.Background(), header)
+	assert.Equal(t, "Halo, Dunia!", i18n.TCtx(ctx, "hello_world"))
+}
+
+func TestEnvelope(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	type welcomeEmail struct {
+		Name string `json:"name"`
+	}
+
+	ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+	buf, err := json.Marshal(i18n.NewEnvelope(ctx, welcomeEmail{Name: "John"}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"headers":{"Accept-Language":"id"},"payload":{"name":"John"}}`, string(buf))
+
+	var envelope i18n.Envelope[welcomeEmail]
+	require.NoError(t, json.Unmarshal(buf, &envelope))
+	ctx = envelope.Context(context.Background())
+	assert.Equal(t, "Halo John", i18n.TCtx(ctx, "hello", i18n.Param("name", envelope.Payload.Name)))
+}
@@ -0,0 +1,165 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var (
+	// PseudoAccented is the pseudo-locale that accents, expands and brackets the messages of the default language.
+	PseudoAccented = language.MustParse("en-XA")
+	// PseudoBidi is the pseudo-locale that renders the messages of the default language right-to-left.
+	PseudoBidi = language.MustParse("ar-XB")
+)
+
+const (
+	pseudoExpansion = 0.4
+	pseudoPadding   = " one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen"
+	rlm             = "\u200f"
+	rlo             = "\u202e"
+	pdf             = "\u202c"
+)
+
+var pseudoAccents = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ',
+	'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ',
+	'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ',
+	'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ',
+	'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+var pseudoProtected = pseudoProtectedRegexp("", "")
+
+// pseudoProtectedRegexp matches the template actions, HTML tags and entities that are kept intact.
+func pseudoProtectedRegexp(leftDelim, rightDelim string) *regexp.Regexp {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(leftDelim) + `.*?` + regexp.QuoteMeta(rightDelim) + `|<[^>]*>|&#?[a-zA-Z0-9]+;`)
+}
+
+// WithPseudoLocalization enables the pseudo-locales PseudoAccented (en-XA) and PseudoBidi (ar-XB).
+//
+// When the preferred language is a pseudo-locale, GetCtx transforms the message of the default language
+// while keeping the template actions, HTML tags and entities intact. It helps to find hardcoded strings,
+// truncated layouts and bidi issues in the UI. It should only be enabled in development and testing.
+func WithPseudoLocalization() Option {
+	return func(c *config) {
+		c.pseudoLocalization = true
+	}
+}
+
+func addPseudoMessages(pseudo *i18n.Bundle, messages []*i18n.Message, leftDelim, rightDelim string) error {
+	for _, tag := range []language.Tag{PseudoAccented, PseudoBidi} {
+		pseudoMessages := make([]*i18n.Message, 0, len(messages))
+		for _, message := range messages {
+			pseudoMessages = append(pseudoMessages, pseudoMessage(message, tag, leftDelim, rightDelim))
+		}
+		if err := pseudo.AddMessages(tag, pseudoMessages...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newPseudoLocalizer returns a localizer of the pseudo bundle if the language is a pseudo-locale.
+func newPseudoLocalizer(pseudo *i18n.Bundle, lang string) *i18n.Localizer {
+	if pseudo == nil {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(lang)
+	if err != nil || len(tags) == 0 {
+		return nil
+	}
+	if tags[0] != PseudoAccented && tags[0] != PseudoBidi {
+		return nil
+	}
+	return i18n.NewLocalizer(pseudo, tags[0].String())
+}
+
+func pseudoMessage(message *i18n.Message, tag language.Tag, leftDelim, rightDelim string) *i18n.Message {
+	m := *message
+	leftDelim, rightDelim = messageDelims(&m, leftDelim, rightDelim)
+	for _, form := range []*string{&m.Zero, &m.One, &m.Two, &m.Few, &m.Many, &m.Other} {
+		if *form != "" {
+			*form = pseudoLocalize(*form, tag, leftDelim, rightDelim)
+		}
+	}
+	return &m
+}
+
+// pseudoLocalize transforms the text of the template for the pseudo-locale.
+func pseudoLocalize(s string, tag language.Tag, leftDelim, rightDelim string) string {
+	protected := pseudoProtected
+	if leftDelim != "" || rightDelim != "" {
+		protected = pseudoProtectedRegexp(leftDelim, rightDelim)
+	}
+
+	var (
+		b       strings.Builder
+		letters int
+		last    int
+	)
+	transform := func(text string) {
+		if tag == PseudoBidi {
+			b.WriteString(bidiWords(text))
+			return
+		}
+		for _, r := range text {
+			if accented, ok := pseudoAccents[r]; ok {
+				b.WriteRune(accented)
+			} else {
+				b.WriteRune(r)
+			}
+			if unicode.IsLetter(r) {
+				letters++
+			}
+		}
+	}
+	for _, loc := range protected.FindAllStringIndex(s, -1) {
+		transform(s[last:loc[0]])
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	transform(s[last:])
+
+	if tag == PseudoBidi {
+		return b.String()
+	}
+	padding := int(float64(letters)*pseudoExpansion + 0.5)
+	if padding > len(pseudoPadding) {
+		padding = len(pseudoPadding)
+	}
+	return "[" + b.String() + strings.TrimRight(pseudoPadding[:padding], " ") + "]"
+}
+
+// bidiWords wraps every word in right-to-left override marks.
+func bidiWords(text string) string {
+	var b strings.Builder
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) {
+			if start >= 0 {
+				b.WriteString(rlm + rlo + text[start:i] + pdf + rlm)
+				start = -1
+			}
+			b.WriteRune(r)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		b.WriteString(rlm + rlo + text[start:] + pdf + rlm)
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPseudoLocalization(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithPseudoLocalization(),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		language        string
+		messageID       string
+		options         []any
+		expectedMessage string
+	}{
+		{
+			name:            "accented",
+			language:        "en-XA",
+			messageID:       "hello_world",
+			expectedMessage: "[Ĥéļļö, Ŵöŕļð! one]",
+		},
+		{
+			name:            "accented keeps params",
+			language:        "en-XA",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", "John")},
+			expectedMessage: "[Ĥéļļö, John! o]",
+		},
+		{
+			name:            "accented keeps html tags",
+			language:        "en-XA,en",
+			messageID:       "terms",
+			options:         []any{i18n.Params{"url": "/terms", "name": "John"}},
+			expectedMessage: `[Ŕéáð ţĥé <a href="/terms">ţéŕɱš</a>, John one]`,
+		},
+		{
+			name:            "accented plural",
+			language:        "en-XA",
+			messageID:       "apple",
+			options:         []any{i18n.Plural(2)},
+			expectedMessage: "[2 áþþļéš o]",
+		},
+		{
+			name:            "bidi",
+			language:        "ar-XB",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", "John")},
+			expectedMessage: "‏‮Hello‬‏, John!",
+		},
+		{
+			name:            "not pseudo-locale",
+			language:        "en",
+			messageID:       "hello_world",
+			expectedMessage: "Hello, World!",
+		},
+		{
+			name:            "pseudo-locale is not preferred",
+			language:        "id,en-XA",
+			messageID:       "hello_world",
+			expectedMessage: "Halo, Dunia!",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := i18n.NewContextWithLanguage(context.Background(), tc.language)
+			assert.Equal(t, tc.expectedMessage, i18n.TCtx(ctx, tc.messageID, tc.options...))
+		})
+	}
+}
+
+func TestPseudoLocalizationDisabled(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello, World!", i18n.T("hello_world", i18n.Lang("en-XA")))
+}
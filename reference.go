@@ -0,0 +1,272 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// maxReferenceDepth limits the nesting of the message references, which stops the cycles added by Refresh.
+const maxReferenceDepth = 10
+
+// ErrReferenceCycle is returned when the messages reference each other in a cycle.
+var ErrReferenceCycle = errors.New("message reference cycle")
+
+// shorthandReferenceRegexp matches the $t(id) references, which are rewritten to {{t "id"}} when the messages are parsed.
+var shorthandReferenceRegexp = regexp.MustCompile(`\$t\(([^()\s"]+)\)`)
+
+// referenceRegexp matches the {{t "id"}} references with the delimiters.
+func referenceRegexp(leftDelim, rightDelim string) *regexp.Regexp {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	return regexp.MustCompile(regexp.QuoteMeta(leftDelim) + `-?\s*t\s+"([^"]+)"[^` + regexp.QuoteMeta(rightDelim[:1]) + `]*` + regexp.QuoteMeta(rightDelim))
+}
+
+var defaultReferenceRegexp = referenceRegexp("", "")
+
+// prepareMessages rewrites the $t(id) references of the messages to {{t "id"}} with the delimiters of the message,
+// or the default delimiters when it has none. The messages of the files are copied.
+func prepareMessages(files []*i18n.MessageFile, leftDelim, rightDelim string) []*i18n.MessageFile {
+	prepared := make([]*i18n.MessageFile, 0, len(files))
+	for _, file := range files {
+		preparedFile := *file
+		preparedFile.Messages = make([]*i18n.Message, 0, len(file.Messages))
+		for _, message := range file.Messages {
+			m := *message
+			left, right := messageDelims(&m, leftDelim, rightDelim)
+			for _, text := range []*string{&m.Zero, &m.One, &m.Two, &m.Few, &m.Many, &m.Other} {
+				*text = expandShorthandReferences(*text, left, right)
+			}
+			preparedFile.Messages = append(preparedFile.Messages, &m)
+		}
+		prepared = append(prepared, &preparedFile)
+	}
+	return prepared
+}
+
+// messageDelims returns the delimiters of the message, or the default delimiters when it has none.
+func messageDelims(message *i18n.Message, leftDelim, rightDelim string) (string, string) {
+	if message.LeftDelim != "" || message.RightDelim != "" {
+		return message.LeftDelim, message.RightDelim
+	}
+	return leftDelim, rightDelim
+}
+
+// expandShorthandReferences rewrites the $t(id) references of the text to {{t "id"}} with the delimiters.
+func expandShorthandReferences(text, leftDelim, rightDelim string) string {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+	return shorthandReferenceRegexp.ReplaceAllString(text, leftDelim+`t "$1"`+rightDelim)
+}
+
+// messageReferences returns the ids referenced by the plural forms of the prepared message,
+// whose template actions use the default delimiters when it has no delimiters of its own.
+func messageReferences(message *i18n.Message, leftDelim, rightDelim string) map[string][]string {
+	pattern := defaultReferenceRegexp
+	if left, right := messageDelims(message, leftDelim, rightDelim); left != "" || right != "" {
+		pattern = referenceRegexp(left, right)
+	}
+	references := make(map[string][]string)
+	for _, form := range messageForms(message) {
+		for _, match := range pattern.FindAllStringSubmatch(form.text, -1) {
+			references[form.name] = append(references[form.name], match[1])
+		}
+	}
+	return references
+}
+
+// referencingMessages returns the ids of the messages that reference other messages in any language.
+func (c *catalog) referencingMessages() map[string]bool {
+	ids := make(map[string]bool)
+	for _, entries := range c.entries {
+		for id, entry := range entries {
+			if len(messageReferences(entry.prepared, c.leftDelim, c.rightDelim)) > 0 {
+				ids[id] = true
+			}
+		}
+	}
+	return ids
+}
+
+func (c *catalog) lookup(tag, defaultTag language.Tag, id string) *i18n.Message {
+	if entry, ok := c.entries[tag][id]; ok {
+		return entry.prepared
+	}
+	if entry, ok := c.entries[defaultTag][id]; ok {
+		return entry.prepared
+	}
+	return nil
+}
+
+// unresolvedReferences returns the references to the messages that are missing in the language and
+// in the default language.
+func (c *catalog) unresolvedReferences(defaultTag language.Tag) []*ValidationError {
+	var errs []*ValidationError
+	for tag, entries := range c.entries {
+		for id, entry := range entries {
+			for form, references := range messageReferences(entry.prepared, c.leftDelim, c.rightDelim) {
+				for _, reference := range references {
+					if c.lookup(tag, defaultTag, reference) == nil {
+						errs = append(errs, &ValidationError{
+							ID:       id,
+							Language: tag,
+							Form:     form,
+							Reason:   fmt.Sprintf("references the missing message %s", reference),
+						})
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// referenceCycles returns the reference cycles of every language, sorted by language.
+//
+// The messages that are missing in a language are looked up in the default language, as GetCtx does.
+func (c *catalog) referenceCycles(defaultTag language.Tag) []*ValidationError {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	var errs []*ValidationError
+	for tag, entries := range c.entries {
+		state := make(map[string]int)
+		var (
+			path  []string
+			visit func(id string)
+		)
+		visit = func(id string) {
+			switch state[id] {
+			case visiting:
+				for i := range path {
+					if path[i] == id {
+						cycle := append(append([]string(nil), path[i:]...), id)
+						errs = append(errs, &ValidationError{
+							ID:       id,
+							Language: tag,
+							Form:     c.referenceForm(c.lookup(tag, defaultTag, id), cycle[1]),
+							Reason:   fmt.Sprintf("references itself through %s", strings.Join(cycle, " -> ")),
+						})
+						break
+					}
+				}
+				return
+			case visited:
+				return
+			}
+			message := c.lookup(tag, defaultTag, id)
+			if message == nil {
+				return
+			}
+			state[id] = visiting
+			path = append(path, id)
+			var references []string
+			for _, formReferences := range messageReferences(message, c.leftDelim, c.rightDelim) {
+				references = append(references, formReferences...)
+			}
+			sort.Strings(references)
+			for _, reference := range references {
+				visit(reference)
+			}
+			path = path[:len(path)-1]
+			state[id] = visited
+		}
+
+		ids := make([]string, 0, len(entries))
+		for id := range entries {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			visit(id)
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Language.String() < errs[j].Language.String()
+	})
+	return errs
+}
+
+func (c *catalog) referenceForm(message *i18n.Message, reference string) string {
+	for _, form := range messageForms(message) {
+		for _, id := range messageReferences(message, c.leftDelim, c.rightDelim)[form.name] {
+			if id == reference {
+				return form.name
+			}
+		}
+	}
+	return "other"
+}
+
+// checkReferenceCycles returns ErrReferenceCycle when the messages of a language reference each other in a cycle.
+func (c *catalog) checkReferenceCycles(defaultTag language.Tag) error {
+	if cycles := c.referenceCycles(defaultTag); len(cycles) > 0 {
+		return fmt.Errorf("%w: %s", ErrReferenceCycle, cycles[0])
+	}
+	return nil
+}
+
+// reference is a message referenced with the t template function.
+type reference struct {
+	placeholder string
+	id          string
+	opts        []any
+}
+
+// referenceFuncs returns the t template function of the referencing message. It returns a placeholder for
+// the referenced message, which is rendered by renderReferences after the bundle is unlocked.
+func referenceFuncs(cfg *localizeConfig, depth int, references *[]reference) texttemplate.FuncMap {
+	return texttemplate.FuncMap{
+		"t": func(id string, args ...any) (string, error) {
+			if depth >= maxReferenceDepth {
+				return "", fmt.Errorf("%w: %s", ErrReferenceCycle, id)
+			}
+			opts, err := templateParams(args, false)
+			if err != nil {
+				return "", err
+			}
+			if cfg.language != "" {
+				opts = append(opts, Lang(cfg.language))
+			}
+			if cfg.leftDelim != "" || cfg.rightDelim != "" {
+				opts = append(opts, Delims(cfg.leftDelim, cfg.rightDelim))
+			}
+			if cfg.escapeHTML {
+				opts = append(opts, EscapeHTML())
+			}
+			if cfg.noEscapeHTML {
+				opts = append(opts, withoutHTMLEscape())
+			}
+			ref := reference{placeholder: fmt.Sprintf("\x00t%d\x00", len(*references)), id: id, opts: opts}
+			*references = append(*references, ref)
+			return ref.placeholder, nil
+		},
+	}
+}
+
+// renderReferences replaces the placeholders of the message with the referenced messages, rendered with the languages
+// and the template options of the referencing message.
+func renderReferences(ctx context.Context, state *localizeState, message string, references []reference, depth int) string {
+	for _, ref := range references {
+		if strings.Contains(message, ref.placeholder) {
+			message = strings.ReplaceAll(message, ref.placeholder, localize(ctx, state, ref.id, depth+1, ref.opts...))
+		}
+	}
+	return message
+}
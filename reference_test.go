@@ -0,0 +1,167 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestMessageReference(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithMessageFile(
+			&goi18n.MessageFile{
+				Path: "en.yaml",
+				Tag:  language.English,
+				Messages: []*goi18n.Message{
+					{ID: "brand.name", Other: "Acme"},
+					{ID: "brand.tagline", Other: "$t(brand.name), made for {{.name}}"},
+					{ID: "welcome", Other: `Welcome to {{t "brand.name"}}, {{.name}}!`},
+					{ID: "footer", Other: `{{t "brand.tagline" "name" .name}} © 2026`},
+					{ID: "items", One: "One item from $t(brand.name)", Other: "{{.PluralCount}} items from $t(brand.name)"},
+					{ID: "broken", Other: "See $t(missing.id)"},
+				},
+			},
+			&goi18n.MessageFile{
+				Path: "id.yaml",
+				Tag:  language.Indonesian,
+				Messages: []*goi18n.Message{
+					{ID: "brand.name", Other: "Acme Indonesia"},
+					{ID: "welcome", Other: "Selamat datang di $t(brand.name), {{.name}}!"},
+					{ID: "shipping", Other: `Dikirim oleh {{t "brand.tagline" "name" "kamu"}}`},
+				},
+			},
+		),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		language        string
+		messageID       string
+		options         []any
+		expectedMessage string
+	}{
+		{
+			name:            "template reference",
+			messageID:       "welcome",
+			options:         []any{i18n.Param("name", "John")},
+			expectedMessage: "Welcome to Acme, John!",
+		},
+		{
+			name:            "shorthand reference in the same language",
+			language:        "id",
+			messageID:       "welcome",
+			options:         []any{i18n.Param("name", "John")},
+			expectedMessage: "Selamat datang di Acme Indonesia, John!",
+		},
+		{
+			name:            "nested reference with params",
+			messageID:       "footer",
+			options:         []any{i18n.Param("name", "John")},
+			expectedMessage: "Acme, made for John © 2026",
+		},
+		{
+			name:            "reference falls back to default language",
+			language:        "id",
+			messageID:       "shipping",
+			expectedMessage: "Dikirim oleh Acme Indonesia, made for kamu",
+		},
+		{
+			name:            "reference in plural form",
+			messageID:       "items",
+			options:         []any{i18n.Plural(2)},
+			expectedMessage: "2 items from Acme",
+		},
+		{
+			name:            "language option",
+			messageID:       "welcome",
+			options:         []any{i18n.Lang("id"), i18n.Param("name", "John")},
+			expectedMessage: "Selamat datang di Acme Indonesia, John!",
+		},
+		{
+			name:            "unresolved reference",
+			messageID:       "broken",
+			expectedMessage: "See missing.id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.language != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.language)
+			}
+			assert.Equal(t, tc.expectedMessage, i18n.TCtx(ctx, tc.messageID, tc.options...))
+		})
+	}
+
+	message, ok := i18n.Lookup("welcome", language.Indonesian)
+	require.True(t, ok)
+	assert.Equal(t, "Selamat datang di $t(brand.name), {{.name}}!", message.Other)
+
+	assert.Equal(t, []*i18n.ValidationError{
+		{ID: "broken", Language: language.English, Form: "other", Reason: "references the missing message missing.id"},
+	}, i18n.Validate())
+}
+
+func TestMessageReferenceCycle(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithMessageFile(&goi18n.MessageFile{
+			Path: "en.yaml",
+			Tag:  language.English,
+			Messages: []*goi18n.Message{
+				{ID: "a", Other: "A $t(b)"},
+				{ID: "b", One: "B", Other: "B $t(c)"},
+				{ID: "c", Other: `C {{t "a"}}`},
+			},
+		}),
+	)
+	assert.ErrorIs(t, err, i18n.ErrReferenceCycle)
+	assert.EqualError(t, err, "message reference cycle: a (en, other): references itself through a -> b -> c -> a")
+}
+
+type refreshHook struct {
+	id string
+}
+
+func (h refreshHook) BeforeLocalize(ctx context.Context, _ string, _ []string) context.Context {
+	return ctx
+}
+
+func (h refreshHook) AfterLocalize(ctx context.Context, result i18n.LocalizeResult) {
+	if result.ID == h.id {
+		_ = i18n.Refresh(ctx)
+	}
+}
+
+func TestMessageReferenceUnlocked(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithMessageFile(&goi18n.MessageFile{
+			Path: "en.yaml",
+			Tag:  language.English,
+			Messages: []*goi18n.Message{
+				{ID: "brand.name", Other: "Acme"},
+				{ID: "welcome", Other: "Welcome to $t(brand.name)"},
+			},
+		}),
+		i18n.WithHook(refreshHook{id: "brand.name"}),
+	)
+	require.NoError(t, err)
+
+	done := make(chan string)
+	go func() {
+		done <- i18n.T("welcome")
+	}()
+	select {
+	case message := <-done:
+		assert.Equal(t, "Welcome to Acme", message)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the hook of the referenced message runs under the read lock")
+	}
+}
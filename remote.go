@@ -0,0 +1,282 @@
+package i18n
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// SignatureHeader is the response header that contains the hex encoded HMAC-SHA256 signature of the remote catalog.
+const SignatureHeader = "X-Catalog-Signature"
+
+// ErrInvalidSignature is returned when the signature of the remote catalog does not match.
+var ErrInvalidSignature = errors.New("i18n: invalid catalog signature")
+
+type remoteSource struct {
+	url            string
+	client         *http.Client
+	cacheFile      string
+	signatureKey   []byte
+	unmarshalFuncs map[string]i18n.UnmarshalFunc
+
+	mu      sync.Mutex
+	loaded  bool
+	etag    string
+	expires time.Time
+}
+
+// RemoteOption is the option for the remote source.
+type RemoteOption func(*remoteSource)
+
+// RemoteHTTPClient sets the HTTP client used to fetch the catalog. The default is http.DefaultClient.
+func RemoteHTTPClient(client *http.Client) RemoteOption {
+	return func(s *remoteSource) {
+		s.client = client
+	}
+}
+
+// RemoteCacheFile sets the file where the last good catalog is stored.
+//
+// The cached catalog is used when the endpoint is down on Init.
+func RemoteCacheFile(path string) RemoteOption {
+	return func(s *remoteSource) {
+		s.cacheFile = path
+	}
+}
+
+// RemoteSignatureKey sets the key used to verify the HMAC-SHA256 signature of the catalog.
+//
+// The signature is read from the SignatureHeader response header.
+// Catalogs without a valid signature are rejected.
+func RemoteSignatureKey(key []byte) RemoteOption {
+	return func(s *remoteSource) {
+		s.signatureKey = key
+	}
+}
+
+// WithRemoteSource adds a catalog fetched over HTTP to the bundle.
+//
+// The format and language of the catalog are taken from the URL path like the translation files,
+// e.g. https://cdn.example.com/locales/en.yaml. The ETag and Cache-Control response headers are honored
+// by Refresh: the catalog is not fetched again before max-age expires and is revalidated with If-None-Match.
+// When the endpoint is down, the last good catalog is kept in memory or loaded from the cache file,
+// and Refresh returns the error so it can be reported.
+//
+// Example:
+//
+//	i18n.Init(language.English,
+//		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+//		i18n.WithRemoteSource("https://cdn.example.com/locales/id.yaml",
+//			i18n.RemoteCacheFile("/var/cache/app/id.yaml"),
+//		),
+//	)
+func WithRemoteSource(url string, opts ...RemoteOption) Option {
+	return func(c *config) {
+		s := &remoteSource{
+			url:            url,
+			client:         http.DefaultClient,
+			unmarshalFuncs: c.unmarshalFuncMap,
+		}
+		for _, opt := range opts {
+			opt(s)
+		}
+		c.sources = append(c.sources, s)
+	}
+}
+
+func (s *remoteSource) Load(ctx context.Context) ([]*i18n.MessageFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded && time.Now().Before(s.expires) {
+		return nil, nil
+	}
+
+	file, err := s.fetch(ctx)
+	if errors.Is(err, ErrInvalidSignature) {
+		return nil, err
+	}
+	if file != nil {
+		s.loaded = true
+		return []*i18n.MessageFile{file}, nil
+	}
+	if s.loaded {
+		// The catalog is not modified or the endpoint is down, keep the last good catalog.
+		if err != nil {
+			return nil, fmt.Errorf("i18n: failed to load %s: %w", s.url, err)
+		}
+		return nil, nil
+	}
+
+	// The catalog is not modified since the cached one or the endpoint is down, use the cached catalog.
+	cached, cacheErr := s.readCache()
+	if cacheErr != nil {
+		if err == nil {
+			err = cacheErr
+		}
+		return nil, fmt.Errorf("i18n: failed to load %s: %w", s.url, err)
+	}
+	s.loaded = true
+	return []*i18n.MessageFile{cached}, nil
+}
+
+// fetch returns nil without error when the catalog is not modified.
+func (s *remoteSource) fetch(ctx context.Context) (*i18n.MessageFile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	etag := s.etag
+	if !s.loaded && etag == "" {
+		etag = s.readCacheETag()
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		s.etag = etag
+		s.expires = cacheExpires(resp.Header)
+		return nil, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(body, resp.Header.Get(SignatureHeader)); err != nil {
+		return nil, err
+	}
+	file, err := s.parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The etag is kept only once the catalog is cached, otherwise the catalog would not be fetched again.
+	etag = resp.Header.Get("ETag")
+	if err := s.writeCache(body, etag); err != nil {
+		return nil, err
+	}
+	s.etag = etag
+	s.expires = cacheExpires(resp.Header)
+	return file, nil
+}
+
+func (s *remoteSource) verify(body []byte, signature string) error {
+	if s.signatureKey == nil {
+		return nil
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, s.signatureKey)
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *remoteSource) parse(body []byte) (*i18n.MessageFile, error) {
+	path := s.url
+	if u, err := url.Parse(s.url); err == nil {
+		path = u.Path
+	}
+	return i18n.ParseMessageFileBytes(body, path, s.unmarshalFuncs)
+}
+
+func (s *remoteSource) readCache() (*i18n.MessageFile, error) {
+	if s.cacheFile == "" {
+		return nil, os.ErrNotExist
+	}
+	body, err := os.ReadFile(s.cacheFile)
+	if err != nil {
+		return nil, err
+	}
+	s.etag = s.readCacheETag()
+	return s.parse(body)
+}
+
+func (s *remoteSource) readCacheETag() string {
+	if s.cacheFile == "" {
+		return ""
+	}
+	if _, err := os.Stat(s.cacheFile); err != nil {
+		return ""
+	}
+	etag, err := os.ReadFile(s.cacheFile + ".etag")
+	if err != nil {
+		return ""
+	}
+	return string(etag)
+}
+
+func (s *remoteSource) writeCache(body []byte, etag string) error {
+	if s.cacheFile == "" {
+		return nil
+	}
+	if err := writeFileAtomic(s.cacheFile, body); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.cacheFile+".etag", []byte(etag))
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// cacheExpires returns the time until the response is fresh according to the Cache-Control header.
+func cacheExpires(header http.Header) time.Time {
+	now := time.Now()
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(strings.ToLower(directive))
+		if directive == "no-cache" || directive == "no-store" {
+			return now
+		}
+		if strings.HasPrefix(directive, "max-age=") {
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err == nil && seconds > 0 {
+				return now.Add(time.Duration(seconds) * time.Second)
+			}
+		}
+	}
+	return now
+}
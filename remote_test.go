@@ -0,0 +1,197 @@
+package i18n_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+type catalogServer struct {
+	*httptest.Server
+	body         string
+	etag         string
+	cacheControl string
+	signature    string
+	status       int
+	requests     int32
+	notModified  int32
+}
+
+func newCatalogServer(t *testing.T, body string) *catalogServer {
+	s := &catalogServer{body: body, etag: `"v1"`}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.requests, 1)
+		if s.status != 0 {
+			w.WriteHeader(s.status)
+			return
+		}
+		if s.cacheControl != "" {
+			w.Header().Set("Cache-Control", s.cacheControl)
+		}
+		if r.Header.Get("If-None-Match") == s.etag {
+			atomic.AddInt32(&s.notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", s.etag)
+		if s.signature != "" {
+			w.Header().Set(i18n.SignatureHeader, s.signature)
+		}
+		_, _ = w.Write([]byte(s.body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func sign(key []byte, body string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestRemoteSource(t *testing.T) {
+	server := newCatalogServer(t, `test: "Ini adalah pesan tes dari CDN"`)
+	cacheFile := filepath.Join(t.TempDir(), "id.yaml")
+
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+		i18n.WithRemoteSource(server.URL+"/locales/id.yaml", i18n.RemoteCacheFile(cacheFile)),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Ini adalah pesan tes dari CDN", i18n.T("test", i18n.Lang("id")))
+
+	t.Run("revalidate with etag", func(t *testing.T) {
+		require.NoError(t, i18n.Refresh(context.Background()))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&server.notModified))
+		assert.Equal(t, "Ini adalah pesan tes dari CDN", i18n.T("test", i18n.Lang("id")))
+	})
+
+	t.Run("modified catalog", func(t *testing.T) {
+		server.body = `test: "Pesan tes yang diperbarui"`
+		server.etag = `"v2"`
+		server.cacheControl = "max-age=3600"
+		require.NoError(t, i18n.Refresh(context.Background()))
+		assert.Equal(t, "Pesan tes yang diperbarui", i18n.T("test", i18n.Lang("id")))
+	})
+
+	t.Run("fresh catalog is not fetched", func(t *testing.T) {
+		requests := atomic.LoadInt32(&server.requests)
+		require.NoError(t, i18n.Refresh(context.Background()))
+		assert.Equal(t, requests, atomic.LoadInt32(&server.requests))
+	})
+
+	t.Run("catalog is fetched again when the cache write fails", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		cacheFile := filepath.Join(cacheDir, "id.yaml")
+		server.cacheControl = ""
+		err := i18n.Init(language.English,
+			i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+			i18n.WithRemoteSource(server.URL+"/locales/id.yaml", i18n.RemoteCacheFile(cacheFile)),
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, os.RemoveAll(cacheDir))
+		server.body = `test: "Pesan tes yang belum disimpan"`
+		server.etag = `"v3"`
+		assert.Error(t, i18n.Refresh(context.Background()))
+
+		require.NoError(t, os.Mkdir(cacheDir, 0o755))
+		require.NoError(t, i18n.Refresh(context.Background()))
+		assert.Equal(t, "Pesan tes yang belum disimpan", i18n.T("test", i18n.Lang("id")))
+		assert.FileExists(t, cacheFile)
+
+		server.body = `test: "Pesan tes yang diperbarui"`
+		server.etag = `"v2"`
+	})
+
+	t.Run("refresh reports the endpoint error", func(t *testing.T) {
+		server.cacheControl = ""
+		err := i18n.Init(language.English,
+			i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+			i18n.WithRemoteSource(server.URL+"/locales/id.yaml"),
+		)
+		require.NoError(t, err)
+
+		server.status = http.StatusInternalServerError
+		defer func() { server.status = 0 }()
+		err = i18n.Refresh(context.Background())
+		assert.EqualError(t, err, "i18n: failed to load "+server.URL+"/locales/id.yaml: unexpected status 500 Internal Server Error")
+		assert.Equal(t, "Pesan tes yang diperbarui", i18n.T("test", i18n.Lang("id")))
+	})
+
+	t.Run("fallback to cache file when endpoint is down", func(t *testing.T) {
+		url := server.URL + "/locales/id.yaml"
+		server.Close()
+
+		err := i18n.Init(language.English,
+			i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+			i18n.WithRemoteSource(url, i18n.RemoteCacheFile(cacheFile)),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "Pesan tes yang diperbarui", i18n.T("test", i18n.Lang("id")))
+	})
+
+	t.Run("endpoint is down without cache file", func(t *testing.T) {
+		err := i18n.Init(language.English,
+			i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+			i18n.WithRemoteSource(server.URL+"/locales/id.yaml"),
+		)
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoteSourceSignature(t *testing.T) {
+	key := []byte("secret")
+	body := `test: "Pesan bertanda tangan"`
+	server := newCatalogServer(t, body)
+
+	testCases := []struct {
+		name      string
+		signature string
+		wantErr   bool
+	}{
+		{
+			name:      "valid signature",
+			signature: sign(key, body),
+		},
+		{
+			name:      "invalid signature",
+			signature: sign([]byte("other"), body),
+			wantErr:   true,
+		},
+		{
+			name:    "missing signature",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server.signature = tc.signature
+			err := i18n.Init(language.English,
+				i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+				i18n.WithRemoteSource(server.URL+"/id.yaml", i18n.RemoteSignatureKey(key)),
+			)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, i18n.ErrInvalidSignature)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Pesan bertanda tangan", i18n.T("test", i18n.Lang("id")))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+const (
+	// FirstStrongIsolate (U+2068) starts a bidi isolate with the direction of its first strong character.
+	FirstStrongIsolate = "\u2068"
+	// PopDirectionalIsolate (U+2069) ends the bidi isolate.
+	PopDirectionalIsolate = "\u2069"
+)
+
+var rtlScripts = map[string]bool{
+	"Adlm": true, "Arab": true, "Hebr": true, "Mand": true, "Nkoo": true,
+	"Rohg": true, "Samr": true, "Syrc": true, "Thaa": true, "Yezi": true,
+}
+
+// Direction returns the text direction of the language, "rtl" or "ltr".
+//
+// It is driven by the script of the language, so ar, he, fa and ur are "rtl" while az-Latn is "ltr".
+//
+// Example:
+//
+//	dir := i18n.Direction(language.Arabic) // rtl
+func Direction(tag language.Tag) string {
+	script, _ := tag.Script()
+	if rtlScripts[script.String()] {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// IsRTL reports whether the messages for the context are written right-to-left.
+//
+// It uses the resolved language, which is the language of the bundle that GetCtx uses for the context,
+// so a context with an unsupported right-to-left language falls back to the direction of the default language.
+//
+// Example:
+//
+//	if i18n.IsRTL(r.Context()) {
+//		class = "rtl"
+//	}
+func IsRTL(ctx context.Context) bool {
+	return Direction(resolveLanguage(ctx)) == "rtl"
+}
+
+// WithBidiIsolation wraps the values of the params in Unicode isolate marks (FSI and PDI) for every message.
+//
+// It keeps interpolated names and numbers from breaking the layout of mixed-direction text, e.g. a Latin user name
+// in an Arabic sentence. Strings, fmt.Stringer values and numbers are wrapped, other values are left as is.
+func WithBidiIsolation() Option {
+	return func(c *config) {
+		c.bidiIsolation = true
+	}
+}
+
+// Isolate wraps the values of the params in Unicode isolate marks (FSI and PDI) for the message.
+//
+// It is the per message form of WithBidiIsolation.
+//
+// Example:
+//
+//	i18n.T("hello", i18n.Param("name", user.Name), i18n.Isolate())
+func Isolate() LocalizeOption {
+	return func(c *localizeConfig) {
+		c.isolate = true
+	}
+}
+
+func isolateParams(params map[string]interface{}) {
+	for key, value := range params {
+		params[key] = isolateValue(value)
+	}
+}
+
+func isolateValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case htmltemplate.HTML:
+		return htmltemplate.HTML(FirstStrongIsolate + string(v) + PopDirectionalIsolate)
+	case string:
+		return FirstStrongIsolate + v + PopDirectionalIsolate
+	case fmt.Stringer:
+		return FirstStrongIsolate + v.String() + PopDirectionalIsolate
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return FirstStrongIsolate + fmt.Sprint(value) + PopDirectionalIsolate
+	}
+	return value
+}
+
+// resolveLanguage returns the language of the bundle that matches the language of the context.
+func resolveLanguage(ctx context.Context) language.Tag {
+	mu.RLock()
+	b, fallback, extract, pseudo := bundle, defaultLanguage, extractLanguageFunc, pseudoBundle
+	mu.RUnlock()
+	if b == nil {
+		panic(ErrI18nNotInitialized)
+	}
+
+	languages := requestedLanguages(ctx, "", extract, fallback)
+	if pseudo != nil {
+		if tag, err := language.Parse(languages[0]); err == nil && (tag == PseudoAccented || tag == PseudoBidi) {
+			return tag
+		}
+	}
+	return matchLanguage(b, languages)
+}
+
+func matchLanguage(b *i18n.Bundle, languages []string) language.Tag {
+	mu.RLock()
+	supported := b.LanguageTags()
+	mu.RUnlock()
+
+	var desired []language.Tag
+	for _, lang := range languages {
+		tags, _, err := language.ParseAcceptLanguage(lang)
+		if err == nil {
+			desired = append(desired, tags...)
+		}
+	}
+	// The matcher returns the first supported language, the default language, when nothing matches.
+	_, index, _ := language.NewMatcher(supported).Match(desired...)
+	return supported[index]
+}
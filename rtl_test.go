@@ -0,0 +1,128 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDirection(t *testing.T) {
+	testCases := []struct {
+		tag      language.Tag
+		expected string
+	}{
+		{tag: language.English, expected: "ltr"},
+		{tag: language.Indonesian, expected: "ltr"},
+		{tag: language.Arabic, expected: "rtl"},
+		{tag: language.Hebrew, expected: "rtl"},
+		{tag: language.Persian, expected: "rtl"},
+		{tag: language.Urdu, expected: "rtl"},
+		{tag: language.MustParse("az-Latn"), expected: "ltr"},
+		{tag: language.MustParse("az-Arab"), expected: "rtl"},
+		{tag: i18n.PseudoBidi, expected: "rtl"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tag.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expected, i18n.Direction(tc.tag))
+		})
+	}
+}
+
+func TestIsRTL(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/ar.yaml"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		language string
+		expected bool
+	}{
+		{name: "default language", expected: false},
+		{name: "supported rtl language", language: "ar", expected: true},
+		{name: "regional rtl language", language: "ar-EG", expected: true},
+		{name: "unsupported rtl language", language: "he", expected: false},
+		{name: "preferred ltr language", language: "en,ar", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.language != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.language)
+			}
+			assert.Equal(t, tc.expected, i18n.IsRTL(ctx))
+		})
+	}
+}
+
+func TestBidiIsolation(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/ar.yaml"),
+	)
+	require.NoError(t, err)
+
+	ctx := i18n.NewContextWithLanguage(context.Background(), "ar")
+	assert.Equal(t, "مرحبا John", i18n.GetCtx(ctx, "hello", i18n.Param("name", "John")))
+	assert.Equal(t, "مرحبا \u2068John\u2069", i18n.GetCtx(ctx, "hello", i18n.Param("name", "John"), i18n.Isolate()))
+
+	err = i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/ar.yaml"),
+		i18n.WithBidiIsolation(),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		messageID       string
+		options         []any
+		expectedMessage string
+	}{
+		{
+			name:            "string",
+			messageID:       "hello",
+			options:         []any{i18n.Lang("ar"), i18n.Param("name", "John")},
+			expectedMessage: "مرحبا \u2068John\u2069",
+		},
+		{
+			name:            "number",
+			messageID:       "hello_age",
+			options:         []any{i18n.Params{"name": "John", "age": 30}},
+			expectedMessage: "Hello, \u2068John\u2069! You are \u206830\u2069 years old.",
+		},
+		{
+			name:            "plural count",
+			messageID:       "apple",
+			options:         []any{i18n.Plural(2)},
+			expectedMessage: "\u20682\u2069 apples",
+		},
+		{
+			name:            "stringer",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", language.Arabic)},
+			expectedMessage: "Hello, \u2068ar\u2069!",
+		},
+		{
+			name:            "other value",
+			messageID:       "hello",
+			options:         []any{i18n.Param("name", true)},
+			expectedMessage: "Hello, true!",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMessage, i18n.T(tc.messageID, tc.options...))
+		})
+	}
+}
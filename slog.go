@@ -0,0 +1,88 @@
+//go:build go1.21
+
+package i18n
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogLanguageKey is the attribute key of the language added by the log handler.
+const LogLanguageKey = "lang"
+
+type logHandler struct {
+	next slog.Handler
+}
+
+// NewLogHandler returns a slog.Handler that adds the language of the context to the records.
+//
+// The language is added as the LogLanguageKey attribute and the records are passed to the next handler.
+//
+// Example:
+//
+//	logger := slog.New(i18n.NewLogHandler(slog.NewJSONHandler(os.Stdout, nil)))
+//	logger.InfoContext(ctx, "user signed in")
+//	// {"level":"INFO","msg":"user signed in","lang":"id"}
+func NewLogHandler(next slog.Handler) slog.Handler {
+	return &logHandler{next: next}
+}
+
+func (h *logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *logHandler) Handle(ctx context.Context, record slog.Record) error {
+	record = record.Clone()
+	record.AddAttrs(slog.String(LogLanguageKey, GetLanguage(ctx).String()))
+	return h.next.Handle(ctx, record)
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &logHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{next: h.next.WithGroup(name)}
+}
+
+// LogValue is a localized message logged in a language neutral way.
+//
+// It is logged as a group with the message ID, the params and the message in the default language,
+// so the logs can be searched by the message ID regardless of the language of the user.
+//
+// Example:
+//
+//	logger.Info("notification sent", "message", i18n.NewLogValue("hello", i18n.Params{"name": "John"}))
+//	// {"msg":"notification sent","message":{"id":"hello","params":{"name":"John"},"text":"Hello, John!"}}
+type LogValue struct {
+	ID     string
+	Params Params
+}
+
+// NewLogValue returns a LogValue of the message ID and params.
+func NewLogValue(id string, params Params) LogValue {
+	return LogValue{ID: id, Params: params}
+}
+
+// LogValue implements slog.LogValuer.
+func (v LogValue) LogValue() slog.Value {
+	return messageLogValue(v.ID, v.Params)
+}
+
+// LogValue implements slog.LogValuer. The error is logged like LogValue with its cause.
+func (e *Error) LogValue() slog.Value {
+	value := messageLogValue(e.ID, e.Params)
+	if e.Err == nil {
+		return value
+	}
+	return slog.GroupValue(append(value.Group(), slog.String("cause", e.Err.Error()))...)
+}
+
+func messageLogValue(id string, params Params) slog.Value {
+	attrs := []slog.Attr{slog.String("id", id)}
+	if len(params) > 0 {
+		attrs = append(attrs, slog.Any("params", map[string]interface{}(params)))
+	}
+	attrs = append(attrs, slog.String("text", defaultLanguageMessage(id, params)))
+	return slog.GroupValue(attrs...)
+}
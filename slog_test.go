@@ -0,0 +1,101 @@
+//go:build go1.21
+
+package i18n_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLogHandler(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	logger := slog.New(i18n.NewLogHandler(slog.NewJSONHandler(&buf, nil))).With("service", "test")
+
+	testCases := []struct {
+		name     string
+		lang     string
+		log      func(ctx context.Context)
+		expected map[string]any
+	}{
+		{
+			name: "without language",
+			log: func(ctx context.Context) {
+				logger.InfoContext(ctx, "user signed in")
+			},
+			expected: map[string]any{"msg": "user signed in", "service": "test", "lang": "en"},
+		},
+		{
+			name: "with language",
+			lang: "id-ID,en",
+			log: func(ctx context.Context) {
+				logger.InfoContext(ctx, "user signed in")
+			},
+			expected: map[string]any{"msg": "user signed in", "service": "test", "lang": "id-ID"},
+		},
+		{
+			name: "with log value",
+			lang: "id",
+			log: func(ctx context.Context) {
+				logger.InfoContext(ctx, "greeting sent", "message", i18n.NewLogValue("hello", i18n.Params{"name": "John"}))
+			},
+			expected: map[string]any{
+				"msg":     "greeting sent",
+				"service": "test",
+				"lang":    "id",
+				"message": map[string]any{"id": "hello", "params": map[string]any{"name": "John"}, "text": "Hello, John!"},
+			},
+		},
+		{
+			name: "with error",
+			lang: "id",
+			log: func(ctx context.Context) {
+				err := i18n.WrapError(errors.New("no rows"), "user_not_found", i18n.Params{"name": "John"})
+				logger.ErrorContext(ctx, "request failed", "error", err)
+			},
+			expected: map[string]any{
+				"msg":     "request failed",
+				"service": "test",
+				"lang":    "id",
+				"error": map[string]any{
+					"id":     "user_not_found",
+					"params": map[string]any{"name": "John"},
+					"text":   "User John not found",
+					"cause":  "no rows",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf.Reset()
+			ctx := context.Background()
+			if tc.lang != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.lang)
+			}
+			tc.log(ctx)
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			delete(record, "time")
+			delete(record, "level")
+			assert.Equal(t, tc.expected, record)
+		})
+	}
+}
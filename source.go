@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"context"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// Source is a source of message files other than the translation files, e.g. a database.
+//
+// Load is called by Init to load all messages and by Refresh to load the messages changed since the previous call.
+// FullLoad reports which of them calls Load, so a source can reset its state on Init.
+// A source that can not tell which messages changed may return all messages again.
+type Source interface {
+	Load(ctx context.Context) ([]*i18n.MessageFile, error)
+}
+
+const fullLoadCtxKey contextKey = "i18n-full-load"
+
+// FullLoad reports whether Source.Load is called by Init to load all messages, rather than by Refresh.
+func FullLoad(ctx context.Context) bool {
+	full, _ := ctx.Value(fullLoadCtxKey).(bool)
+	return full
+}
+
+// SourceFunc is an adapter to allow the use of ordinary functions as Source.
+type SourceFunc func(ctx context.Context) ([]*i18n.MessageFile, error)
+
+// Load calls f(ctx).
+func (f SourceFunc) Load(ctx context.Context) ([]*i18n.MessageFile, error) {
+	return f(ctx)
+}
+
+// Refresh loads the changed messages from the sources and adds them to the bundle.
+//
+// When a source fails, the messages of the other sources are still added and the error of the source is returned.
+//
+// It is safe to call Refresh while other goroutines are translating messages.
+//
+// Example:
+//
+//	go func() {
+//		for range time.Tick(time.Minute) {
+//			if err := i18n.Refresh(ctx); err != nil {
+//				log.Println(err)
+//			}
+//		}
+//	}()
+func Refresh(ctx context.Context) error {
+	mu.RLock()
+	b, c, pseudo, srcs, tenantBundles := bundle, messageCatalog, pseudoBundle, sources, tenants
+	mu.RUnlock()
+	if b == nil {
+		return ErrI18nNotInitialized
+	}
+
+	// The messages of the other sources are added even if a source fails, the error is returned after.
+	files, loadErr := loadSources(ctx, srcs)
+
+	mu.Lock()
+	defer mu.Unlock()
+	prepared, err := addMessageFiles(b, c, files)
+	if err != nil {
+		return err
+	}
+	references := c.referencingMessages()
+	for id := range referencingMessages {
+		references[id] = true
+	}
+	referencingMessages = references
+	if pseudo != nil {
+		for _, file := range prepared {
+			if file.Tag == defaultLanguage {
+				if err := addPseudoMessages(pseudo, file.Messages, c.leftDelim, c.rightDelim); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := syncTenantTags(b, tenantBundles); err != nil {
+		return err
+	}
+	return loadErr
+}
+
+func loadSources(ctx context.Context, srcs []Source) ([]*i18n.MessageFile, error) {
+	var (
+		files    []*i18n.MessageFile
+		firstErr error
+	)
+	for _, source := range srcs {
+		loaded, err := source.Load(ctx)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		files = append(files, loaded...)
+	}
+	return files, firstErr
+}
@@ -0,0 +1,50 @@
+package i18n_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSource(t *testing.T) {
+	greeting := "Halo dari sumber"
+	source := i18n.SourceFunc(func(ctx context.Context) ([]*goi18n.MessageFile, error) {
+		return []*goi18n.MessageFile{
+			{
+				Tag:      language.Indonesian,
+				Messages: []*goi18n.Message{{ID: "from_source", Other: greeting}},
+			},
+		}, nil
+	})
+
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithSource(source),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Ini adalah pesan tes", i18n.T("test", i18n.Lang("id")))
+	assert.Equal(t, "Halo dari sumber", i18n.T("from_source", i18n.Lang("id")))
+
+	greeting = "Halo dari sumber yang diperbarui"
+	require.NoError(t, i18n.Refresh(context.Background()))
+	assert.Equal(t, "Halo dari sumber yang diperbarui", i18n.T("from_source", i18n.Lang("id")))
+}
+
+func TestSourceError(t *testing.T) {
+	errSource := errors.New("source is down")
+	source := i18n.SourceFunc(func(ctx context.Context) ([]*goi18n.MessageFile, error) {
+		return nil, errSource
+	})
+
+	err := i18n.Init(language.English, i18n.WithSource(source))
+	assert.ErrorIs(t, err, errSource)
+}
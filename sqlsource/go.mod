@@ -0,0 +1,19 @@
+module github.com/ahmadfaizk/i18n/sqlsource
+
+go 1.19
+
+require (
+	github.com/ahmadfaizk/i18n v0.1.0
+	github.com/mattn/go-sqlite3 v1.14.22
+	github.com/nicksnyder/go-i18n/v2 v2.4.0
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/text v0.17.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/ahmadfaizk/i18n => ../
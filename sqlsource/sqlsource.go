@@ -0,0 +1,239 @@
+// Package sqlsource provides an i18n.Source that loads messages from a database/sql database.
+//
+// The messages are stored one row per language, message id and plural category:
+//
+//	CREATE TABLE translations (
+//		language   TEXT      NOT NULL,
+//		id         TEXT      NOT NULL,
+//		plural     TEXT      NOT NULL DEFAULT 'other',
+//		text       TEXT      NOT NULL,
+//		updated_at TIMESTAMP NOT NULL,
+//		PRIMARY KEY (language, id, plural)
+//	);
+//
+// The plural column is one of the CLDR plural categories: zero, one, two, few, many or other.
+// Messages without plural forms only have the other row.
+//
+// The first Load returns all messages. The next calls only return the messages with a row
+// updated since the latest updated_at seen so far, so i18n.Refresh is cheap to call periodically.
+// Deleted rows are not detected by the incremental refresh. i18n.Init loads all messages again,
+// so calling it again with the same Source drops them.
+//
+// Example:
+//
+//	db, err := sql.Open("sqlite3", "translations.db")
+//	if err != nil {
+//		panic(err)
+//	}
+//	if err := i18n.Init(language.English,
+//		i18n.WithTranslationFile("locales/en.yaml"),
+//		i18n.WithSource(sqlsource.New(db)),
+//	); err != nil {
+//		panic(err)
+//	}
+package sqlsource
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ahmadfaizk/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// Schema is the SQL statement that creates the default translations table.
+const Schema = `CREATE TABLE IF NOT EXISTS translations (
+	language   TEXT      NOT NULL,
+	id         TEXT      NOT NULL,
+	plural     TEXT      NOT NULL DEFAULT 'other',
+	text       TEXT      NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (language, id, plural)
+)`
+
+const defaultTable = "translations"
+
+// Source loads messages from a database table.
+type Source struct {
+	db    *sql.DB
+	query string
+
+	mu        sync.Mutex
+	updatedAt time.Time
+	// seen keeps the text of the rows updated at updatedAt. The rows updated at updatedAt are selected again,
+	// because a row committed later may have the same updated_at, and the seen rows are skipped.
+	seen     map[rowKey]string
+	messages map[language.Tag]map[string]*goi18n.Message
+}
+
+type rowKey struct {
+	language, id, plural string
+}
+
+// Option is the option for the Source.
+type Option func(*Source)
+
+// New creates a Source that loads messages from the translations table of the database.
+func New(db *sql.DB, opts ...Option) *Source {
+	s := &Source{
+		db:       db,
+		query:    tableQuery(defaultTable),
+		messages: make(map[language.Tag]map[string]*goi18n.Message),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func tableQuery(table string) string {
+	return fmt.Sprintf("SELECT language, id, plural, text, updated_at FROM %s WHERE updated_at >= ? ORDER BY updated_at", table)
+}
+
+// WithTable sets the name of the table. The table must have the columns of Schema.
+func WithTable(table string) Option {
+	return func(s *Source) {
+		s.query = tableQuery(table)
+	}
+}
+
+// WithQuery sets the query used to load the messages.
+//
+// The query must select the language, id, plural, text and updated_at columns in this order
+// and filter the rows updated at or after its only argument, e.g. for PostgreSQL:
+//
+//	SELECT language, id, plural, text, updated_at FROM translations WHERE updated_at >= $1
+func WithQuery(query string) Option {
+	return func(s *Source) {
+		s.query = query
+	}
+}
+
+// Load returns the messages updated since the previous call. The first call and the calls by i18n.Init
+// return all messages.
+//
+// The whole message is returned when any of its plural forms is updated.
+func (s *Source) Load(ctx context.Context) ([]*goi18n.MessageFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i18n.FullLoad(ctx) {
+		s.updatedAt = time.Time{}
+		s.seen = nil
+		s.messages = make(map[language.Tag]map[string]*goi18n.Message)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.query, s.updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updatedAt := s.updatedAt
+	seen := make(map[rowKey]string)
+	changed := make(map[language.Tag]map[string]bool)
+	for rows.Next() {
+		var (
+			lang, id, plural, text string
+			rowUpdatedAt           time.Time
+		)
+		if err := rows.Scan(&lang, &id, &plural, &text, &rowUpdatedAt); err != nil {
+			return nil, err
+		}
+		key := rowKey{language: lang, id: id, plural: plural}
+		if seenText, ok := s.seen[key]; ok && seenText == text && rowUpdatedAt.Equal(s.updatedAt) {
+			continue
+		}
+		tag, err := language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language of message %q: %w", id, err)
+		}
+		message := s.message(tag, id)
+		if !setPluralForm(message, plural, text) {
+			return nil, fmt.Errorf("invalid plural category %q of message %q", plural, id)
+		}
+		if changed[tag] == nil {
+			changed[tag] = make(map[string]bool)
+		}
+		changed[tag][id] = true
+		if rowUpdatedAt.After(updatedAt) {
+			updatedAt = rowUpdatedAt
+			seen = make(map[rowKey]string)
+		}
+		if rowUpdatedAt.Equal(updatedAt) {
+			seen[key] = text
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if updatedAt.Equal(s.updatedAt) {
+		for key, text := range s.seen {
+			if _, ok := seen[key]; !ok {
+				seen[key] = text
+			}
+		}
+	}
+	s.updatedAt = updatedAt
+	s.seen = seen
+
+	tags := make([]language.Tag, 0, len(changed))
+	for tag := range changed {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].String() < tags[j].String() })
+
+	files := make([]*goi18n.MessageFile, 0, len(tags))
+	for _, tag := range tags {
+		ids := make([]string, 0, len(changed[tag]))
+		for id := range changed[tag] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		file := &goi18n.MessageFile{Path: "sql", Tag: tag, Format: "sql"}
+		for _, id := range ids {
+			message := *s.messages[tag][id]
+			file.Messages = append(file.Messages, &message)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func (s *Source) message(tag language.Tag, id string) *goi18n.Message {
+	if s.messages[tag] == nil {
+		s.messages[tag] = make(map[string]*goi18n.Message)
+	}
+	message, ok := s.messages[tag][id]
+	if !ok {
+		message = &goi18n.Message{ID: id}
+		s.messages[tag][id] = message
+	}
+	return message
+}
+
+func setPluralForm(m *goi18n.Message, category, form string) bool {
+	switch category {
+	case "zero":
+		m.Zero = form
+	case "one":
+		m.One = form
+	case "two":
+		m.Two = form
+	case "few":
+		m.Few = form
+	case "many":
+		m.Many = form
+	case "other", "":
+		m.Other = form
+	default:
+		return false
+	}
+	return true
+}
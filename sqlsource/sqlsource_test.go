@@ -0,0 +1,126 @@
+package sqlsource_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/ahmadfaizk/i18n/sqlsource"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec(sqlsource.Schema)
+	require.NoError(t, err)
+	return db
+}
+
+func upsert(t *testing.T, db *sql.DB, lang, id, plural, text string, updatedAt time.Time) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO translations (language, id, plural, text, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (language, id, plural) DO UPDATE SET text = excluded.text, updated_at = excluded.updated_at`,
+		lang, id, plural, text, updatedAt)
+	require.NoError(t, err)
+}
+
+func TestSource(t *testing.T) {
+	db := openDB(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	upsert(t, db, "en", "hello", "other", "Hello, {{.name}}!", now)
+	upsert(t, db, "id", "hello", "other", "Halo, {{.name}}!", now)
+	upsert(t, db, "en", "apple", "one", "{{.PluralCount}} apple", now)
+	upsert(t, db, "en", "apple", "other", "{{.PluralCount}} apples", now)
+
+	source := sqlsource.New(db)
+	files, err := source.Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, language.English, files[0].Tag)
+	assert.Len(t, files[0].Messages, 2)
+	assert.Equal(t, language.Indonesian, files[1].Tag)
+
+	t.Run("nothing changed", func(t *testing.T) {
+		files, err := source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("plural form changed", func(t *testing.T) {
+		upsert(t, db, "en", "apple", "one", "One apple", now.Add(time.Minute))
+
+		files, err := source.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		require.Len(t, files[0].Messages, 1)
+		assert.Equal(t, "One apple", files[0].Messages[0].One)
+		assert.Equal(t, "{{.PluralCount}} apples", files[0].Messages[0].Other)
+	})
+
+	t.Run("row committed later with the same updated_at", func(t *testing.T) {
+		upsert(t, db, "id", "bye", "other", "Sampai jumpa", now.Add(time.Minute))
+
+		files, err := source.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		require.Len(t, files[0].Messages, 1)
+		assert.Equal(t, "bye", files[0].Messages[0].ID)
+
+		files, err = source.Load(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+
+	t.Run("invalid plural category", func(t *testing.T) {
+		upsert(t, db, "en", "broken", "several", "Broken", now.Add(2*time.Minute))
+
+		_, err := source.Load(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestSourceWithInit(t *testing.T) {
+	db := openDB(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	upsert(t, db, "en", "hello", "other", "Hello, {{.name}}!", now)
+	upsert(t, db, "id", "hello", "other", "Halo, {{.name}}!", now)
+
+	err := i18n.Init(language.English, i18n.WithSource(sqlsource.New(db)))
+	require.NoError(t, err)
+	assert.Equal(t, "Halo, John!", i18n.T("hello", i18n.Lang("id"), i18n.Param("name", "John")))
+
+	upsert(t, db, "id", "hello", "other", "Hai, {{.name}}!", now.Add(time.Minute))
+	upsert(t, db, "id", "bye", "other", "Sampai jumpa", now.Add(time.Minute))
+	require.NoError(t, i18n.Refresh(context.Background()))
+
+	assert.Equal(t, "Hai, John!", i18n.T("hello", i18n.Lang("id"), i18n.Param("name", "John")))
+	assert.Equal(t, "Sampai jumpa", i18n.T("bye", i18n.Lang("id")))
+	assert.Equal(t, "Hello, John!", i18n.T("hello", i18n.Param("name", "John")))
+}
+
+func TestSourceReinit(t *testing.T) {
+	db := openDB(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	upsert(t, db, "en", "hello", "other", "Hello!", now)
+	upsert(t, db, "en", "bye", "other", "Bye!", now)
+
+	source := sqlsource.New(db)
+	require.NoError(t, i18n.Init(language.English, i18n.WithSource(source)))
+	assert.Equal(t, "Bye!", i18n.T("bye"))
+
+	_, err := db.Exec(`DELETE FROM translations WHERE id = 'bye'`)
+	require.NoError(t, err)
+	require.NoError(t, i18n.Init(language.English, i18n.WithSource(source)))
+	assert.Equal(t, "Hello!", i18n.T("hello"))
+	assert.Equal(t, "bye", i18n.T("bye"))
+}
@@ -0,0 +1,175 @@
+package i18n
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+)
+
+// StatsSnapshot is a snapshot of the usage of the messages.
+type StatsSnapshot struct {
+	// Hits is the number of messages found in the language of the context.
+	Hits uint64 `json:"hits"`
+	// Fallbacks is the number of messages served in the default language
+	// because they are missing in the language of the context.
+	Fallbacks uint64 `json:"fallbacks"`
+	// Misses is the number of messages that are not found in any language.
+	Misses uint64 `json:"misses"`
+	// Messages are the counters per message id and language, sorted by id and language.
+	Messages []MessageStats `json:"messages"`
+}
+
+// MessageStats is the usage of a message in a language.
+//
+// The hits are counted in the language of the served message and the fallbacks in the first requested language,
+// not in the language of the served message, so the fallbacks show which languages miss the message.
+type MessageStats struct {
+	ID        string       `json:"id"`
+	Language  language.Tag `json:"language"`
+	Hits      uint64       `json:"hits"`
+	Fallbacks uint64       `json:"fallbacks"`
+	Misses    uint64       `json:"misses"`
+}
+
+type statsKey struct {
+	id       string
+	language language.Tag
+}
+
+type counter struct {
+	hits      uint64
+	fallbacks uint64
+	misses    uint64
+}
+
+type recorder struct {
+	counters sync.Map
+}
+
+func newRecorder() *recorder {
+	return &recorder{}
+}
+
+func (r *recorder) record(result LocalizeResult) {
+	key := statsKey{id: result.ID, language: result.Language}
+	if requested, ok := firstRequestedLanguage(result.Languages); ok && result.Fallback {
+		key.language = requested
+	}
+	value, ok := r.counters.Load(key)
+	if !ok {
+		value, _ = r.counters.LoadOrStore(key, &counter{})
+	}
+	c := value.(*counter)
+	switch {
+	case result.Missing:
+		atomic.AddUint64(&c.misses, 1)
+	case result.Fallback:
+		atomic.AddUint64(&c.fallbacks, 1)
+	default:
+		atomic.AddUint64(&c.hits, 1)
+	}
+}
+
+func (r *recorder) snapshot() StatsSnapshot {
+	stats := StatsSnapshot{Messages: []MessageStats{}}
+	r.counters.Range(func(key, value any) bool {
+		k, c := key.(statsKey), value.(*counter)
+		message := MessageStats{
+			ID:        k.id,
+			Language:  k.language,
+			Hits:      atomic.LoadUint64(&c.hits),
+			Fallbacks: atomic.LoadUint64(&c.fallbacks),
+			Misses:    atomic.LoadUint64(&c.misses),
+		}
+		stats.Hits += message.Hits
+		stats.Fallbacks += message.Fallbacks
+		stats.Misses += message.Misses
+		stats.Messages = append(stats.Messages, message)
+		return true
+	})
+	sort.Slice(stats.Messages, func(i, j int) bool {
+		if stats.Messages[i].ID != stats.Messages[j].ID {
+			return stats.Messages[i].ID < stats.Messages[j].ID
+		}
+		return stats.Messages[i].Language.String() < stats.Messages[j].Language.String()
+	})
+	return stats
+}
+
+// WithStats enables the usage counters of GetCtx.
+//
+// The hits, fallbacks to the default language and misses are counted per message id and language.
+// They are read with Stats, StatsVar or StatsHandler and reset by Init.
+// When it is disabled, GetCtx does not count anything.
+func WithStats() Option {
+	return func(c *config) {
+		c.stats = true
+	}
+}
+
+// Stats returns a snapshot of the usage counters. It is empty when WithStats is not used.
+//
+// Example:
+//
+//	stats := i18n.Stats()
+//	fmt.Printf("hits: %d, fallbacks: %d, misses: %d\n", stats.Hits, stats.Fallbacks, stats.Misses)
+func Stats() StatsSnapshot {
+	mu.RLock()
+	r := statsRecorder
+	mu.RUnlock()
+	if r == nil {
+		return StatsSnapshot{Messages: []MessageStats{}}
+	}
+	return r.snapshot()
+}
+
+// StatsVar returns the usage counters as an expvar variable.
+//
+// Example:
+//
+//	expvar.Publish("i18n", i18n.StatsVar())
+func StatsVar() expvar.Var {
+	return expvar.Func(func() any {
+		return Stats()
+	})
+}
+
+// StatsHandler returns a handler that writes the usage counters in the Prometheus text format.
+//
+// The counter is i18n_messages_total with the id, language and result labels,
+// where the result is hit, fallback or miss.
+//
+// Example:
+//
+//	http.Handle("/metrics/i18n", i18n.StatsHandler())
+func StatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprintln(w, "# HELP i18n_messages_total Number of localized messages by id, language and result.")
+		fmt.Fprintln(w, "# TYPE i18n_messages_total counter")
+		for _, message := range Stats().Messages {
+			for _, result := range []struct {
+				name  string
+				value uint64
+			}{
+				{name: "hit", value: message.Hits},
+				{name: "fallback", value: message.Fallbacks},
+				{name: "miss", value: message.Misses},
+			} {
+				if result.value == 0 {
+					continue
+				}
+				fmt.Fprintf(w, "i18n_messages_total{id=\"%s\",language=\"%s\",result=\"%s\"} %d\n",
+					labelReplacer.Replace(message.ID), message.Language, result.name, result.value)
+			}
+		}
+	})
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
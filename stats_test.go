@@ -0,0 +1,92 @@
+package i18n_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestStats(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithStats(),
+	)
+	require.NoError(t, err)
+
+	ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+	i18n.T("hello_world")
+	i18n.T("hello_world")
+	i18n.TCtx(ctx, "hello_world")
+	i18n.TCtx(ctx, "only_in_en")
+	i18n.TCtx(ctx, "not_found")
+	i18n.T("not_found")
+	i18n.TCtx(i18n.NewContextWithLanguage(context.Background(), "fr"), "hello_world")
+
+	expected := i18n.StatsSnapshot{
+		Hits:      3,
+		Fallbacks: 2,
+		Misses:    2,
+		Messages: []i18n.MessageStats{
+			{ID: "hello_world", Language: language.English, Hits: 2},
+			{ID: "hello_world", Language: language.French, Fallbacks: 1},
+			{ID: "hello_world", Language: language.Indonesian, Hits: 1},
+			{ID: "not_found", Language: language.English, Misses: 1},
+			{ID: "not_found", Language: language.Indonesian, Misses: 1},
+			{ID: "only_in_en", Language: language.Indonesian, Fallbacks: 1},
+		},
+	}
+	assert.Equal(t, expected, i18n.Stats())
+	assert.JSONEq(t, `{
+		"hits": 3,
+		"fallbacks": 2,
+		"misses": 2,
+		"messages": [
+			{"id": "hello_world", "language": "en", "hits": 2, "fallbacks": 0, "misses": 0},
+			{"id": "hello_world", "language": "fr", "hits": 0, "fallbacks": 1, "misses": 0},
+			{"id": "hello_world", "language": "id", "hits": 1, "fallbacks": 0, "misses": 0},
+			{"id": "not_found", "language": "en", "hits": 0, "fallbacks": 0, "misses": 1},
+			{"id": "not_found", "language": "id", "hits": 0, "fallbacks": 0, "misses": 1},
+			{"id": "only_in_en", "language": "id", "hits": 0, "fallbacks": 1, "misses": 0}
+		]
+	}`, i18n.StatsVar().String())
+
+	rec := httptest.NewRecorder()
+	i18n.StatsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP i18n_messages_total Number of localized messages by id, language and result.
+# TYPE i18n_messages_total counter
+i18n_messages_total{id="hello_world",language="en",result="hit"} 2
+i18n_messages_total{id="hello_world",language="fr",result="fallback"} 1
+i18n_messages_total{id="hello_world",language="id",result="hit"} 1
+i18n_messages_total{id="not_found",language="en",result="miss"} 1
+i18n_messages_total{id="not_found",language="id",result="miss"} 1
+i18n_messages_total{id="only_in_en",language="id",result="fallback"} 1
+`, rec.Body.String())
+
+	err = i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+		i18n.WithStats(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, i18n.StatsSnapshot{Messages: []i18n.MessageStats{}}, i18n.Stats())
+}
+
+func TestStatsDisabled(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+	)
+	require.NoError(t, err)
+
+	i18n.T("hello_world")
+	assert.Equal(t, i18n.StatsSnapshot{Messages: []i18n.MessageStats{}}, i18n.Stats())
+}
@@ -0,0 +1,164 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"sync"
+	texttemplate "text/template"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// FuncMap returns the template functions bound to the language of the context for html/template.
+//
+// The functions are:
+//
+//	t       translates the message: {{t "hello" "name" .Name}}
+//	tn      translates the plural message: {{tn "apple" .Count}}
+//	T_html  translates the message without escaping the markup of the catalog: {{T_html "terms" "url" .URL}}
+//	lang    returns the language: <html lang="{{lang}}">
+//	dir     returns the text direction, ltr or rtl: <html dir="{{dir}}">
+//	number  formats the number: {{number 1234.5}}
+//	percent formats the ratio as percentage: {{percent 0.25}}
+//	currency formats the amount with the ISO 4217 currency code: {{currency "USD" 10.5}}
+//
+// The params are given as key and value pairs or as a map. The output of t and tn is escaped by html/template,
+// so WithHTMLEscape does not apply to their params. T_html escapes the params once but keeps the markup of
+// the catalog, so use it only for trusted catalogs.
+//
+// The template must be cloned per request to bind the functions to the request context:
+//
+//	tmpl := template.Must(template.New("page").Funcs(i18n.FuncMap(context.Background())).Parse(page))
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		t := template.Must(tmpl.Clone()).Funcs(i18n.FuncMap(r.Context()))
+//		_ = t.Execute(w, data)
+//	}
+func FuncMap(ctx context.Context) htmltemplate.FuncMap {
+	return htmltemplate.FuncMap(funcMap(ctx, ""))
+}
+
+// TextFuncMap returns the template functions bound to the language of the context for text/template.
+//
+// It provides the same functions as FuncMap.
+func TextFuncMap(ctx context.Context) texttemplate.FuncMap {
+	return texttemplate.FuncMap(funcMap(ctx, ""))
+}
+
+// funcMap returns the template functions for the language, or for the language of the context if it is empty.
+//
+// The lang, dir and number functions use the language of the bundle that the messages are rendered in,
+// not the requested one. It is resolved on first use, so the functions can be created before Init.
+func funcMap(ctx context.Context, lang string) map[string]any {
+	var (
+		once    sync.Once
+		tag     language.Tag
+		printer *message.Printer
+	)
+	resolve := func() {
+		once.Do(func() {
+			if lang == "" {
+				tag = resolveLanguage(ctx)
+			} else {
+				mu.RLock()
+				b, fallback := bundle, defaultLanguage
+				mu.RUnlock()
+				if b == nil {
+					panic(ErrI18nNotInitialized)
+				}
+				tag = matchLanguage(b, []string{lang, fallback.String()})
+			}
+			printer = message.NewPrinter(tag)
+		})
+	}
+	localize := func(id string, opts []any) string {
+		if lang != "" {
+			opts = append(opts, Lang(lang))
+		}
+		return GetCtx(ctx, id, opts...)
+	}
+	return map[string]any{
+		"t": func(id string, args ...any) (string, error) {
+			opts, err := templateParams(args, false)
+			if err != nil {
+				return "", err
+			}
+			return localize(id, append(opts, withoutHTMLEscape())), nil
+		},
+		"tn": func(id string, count any, args ...any) (string, error) {
+			opts, err := templateParams(args, false)
+			if err != nil {
+				return "", err
+			}
+			return localize(id, append(opts, Plural(count), withoutHTMLEscape())), nil
+		},
+		"T_html": func(id string, args ...any) (htmltemplate.HTML, error) {
+			opts, err := templateParams(args, true)
+			if err != nil {
+				return "", err
+			}
+			// The params are escaped and the markup comes from the catalog.
+			return htmltemplate.HTML(localize(id, opts)), nil
+		},
+		"lang": func() string {
+			resolve()
+			return tag.String()
+		},
+		"dir": func() string {
+			resolve()
+			return Direction(tag)
+		},
+		"number": func(value any) string {
+			resolve()
+			return printer.Sprint(number.Decimal(value))
+		},
+		"percent": func(value any) string {
+			resolve()
+			return printer.Sprint(number.Percent(value))
+		},
+		"currency": func(code string, amount any) (string, error) {
+			unit, err := currency.ParseISO(code)
+			if err != nil {
+				return "", err
+			}
+			resolve()
+			return printer.Sprint(currency.Symbol(unit.Amount(amount))), nil
+		},
+	}
+}
+
+// templateParams converts the template arguments to localize options.
+//
+// The arguments are maps or key and value pairs. If escape is true, the values are HTML escaped
+// unless they are already htmltemplate.HTML.
+func templateParams(args []any, escape bool) ([]any, error) {
+	params := Params{}
+	for i := 0; i < len(args); i++ {
+		if m, ok := args[i].(map[string]any); ok {
+			for key, value := range m {
+				params[key] = value
+			}
+			continue
+		}
+		if m, ok := args[i].(Params); ok {
+			for key, value := range m {
+				params[key] = value
+			}
+			continue
+		}
+		key, ok := args[i].(string)
+		if !ok || i+1 >= len(args) {
+			return nil, fmt.Errorf("i18n: invalid template params, expected key and value pairs")
+		}
+		params[key] = args[i+1]
+		i++
+	}
+	if escape {
+		escapeParams(params)
+	}
+	return []any{params}, nil
+}
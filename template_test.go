@@ -0,0 +1,150 @@
+package i18n_test
+
+import (
+	"bytes"
+	"context"
+	htmltemplate "html/template"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFuncMap(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml", "testdata/ar.yaml"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		language string
+		template string
+		data     any
+		expected string
+	}{
+		{
+			name:     "translate",
+			template: `{{t "test"}}`,
+			expected: "This is test message",
+		},
+		{
+			name:     "translate with language",
+			language: "id",
+			template: `{{t "test"}}`,
+			expected: "Ini adalah pesan tes",
+		},
+		{
+			name:     "translate with param pairs",
+			template: `{{t "hello_age" "name" .Name "age" .Age}}`,
+			data:     map[string]any{"Name": "John", "Age": 30},
+			expected: "Hello, John! You are 30 years old.",
+		},
+		{
+			name:     "translate with params map",
+			template: `{{t "hello" .}}`,
+			data:     map[string]any{"name": "John"},
+			expected: "Hello, John!",
+		},
+		{
+			name:     "translate escapes params and markup",
+			template: `{{t "hello" "name" "<b>John</b>"}}`,
+			expected: "Hello, &lt;b&gt;John&lt;/b&gt;!",
+		},
+		{
+			name:     "translate plural",
+			template: `{{tn "apple" 1}} / {{tn "apple" 2}}`,
+			expected: "1 apple / 2 apples",
+		},
+		{
+			name:     "translate html keeps markup and escapes params",
+			language: "id",
+			template: `{{T_html "terms" "url" "/terms" "name" "<b>John</b>"}}`,
+			expected: `Baca <a href="/terms">ketentuan</a>, &lt;b&gt;John&lt;/b&gt;`,
+		},
+		{
+			name:     "language and direction",
+			language: "ar",
+			template: `<html lang="{{lang}}" dir="{{dir}}">`,
+			expected: `<html lang="ar" dir="rtl">`,
+		},
+		{
+			name:     "language and direction of the resolved language",
+			language: "he-IL",
+			template: `<html lang="{{lang}}" dir="{{dir}}">{{t "hello_world"}}</html>`,
+			expected: `<html lang="en" dir="ltr">Hello, World!</html>`,
+		},
+		{
+			name:     "format number in the resolved language",
+			language: "de",
+			template: `{{number 1234.5}}`,
+			expected: "1,234.5",
+		},
+		{
+			name:     "format number",
+			language: "id",
+			template: `{{number 1234.5}} {{percent 0.25}} {{currency "IDR" 15000}}`,
+			expected: "1.234,5 25% Rp 15.000",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.language != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.language)
+			}
+			tmpl, err := htmltemplate.New("test").Funcs(i18n.FuncMap(ctx)).Parse(tc.template)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, tmpl.Execute(&buf, tc.data))
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestFuncMapHTMLEscape(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithHTMLEscape(),
+	)
+	require.NoError(t, err)
+
+	ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+	tmpl, err := htmltemplate.New("test").Funcs(i18n.FuncMap(ctx)).Parse(
+		`{{t "hello" "name" "Tom & Jerry"}} | {{T_html "terms" "url" "/terms" "name" "<b>Tom & Jerry</b>"}}`,
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, `Halo Tom &amp; Jerry | Baca <a href="/terms">ketentuan</a>, &lt;b&gt;Tom &amp; Jerry&lt;/b&gt;`, buf.String())
+	assert.Equal(t, "Halo Tom &amp; Jerry", i18n.TCtx(ctx, "hello", i18n.Param("name", "Tom & Jerry")))
+}
+
+func TestTextFuncMap(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	ctx := i18n.NewContextWithLanguage(context.Background(), "id")
+	tmpl, err := texttemplate.New("test").Funcs(i18n.TextFuncMap(ctx)).Parse(`{{t "hello" "name" "<b>John</b>"}}`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.Equal(t, "Halo <b>John</b>", buf.String())
+
+	tmpl, err = texttemplate.New("test").Funcs(i18n.TextFuncMap(ctx)).Parse(`{{t "hello" "name"}}`)
+	require.NoError(t, err)
+	assert.Error(t, tmpl.Execute(&buf, nil))
+}
@@ -0,0 +1,183 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+const tenantCtxKey contextKey = "i18n-tenant"
+
+type tenantTranslation struct {
+	tenant string
+	files  []string
+	fsFile *translationFSFile
+}
+
+func defaultExtractTenantFunc(ctx context.Context) string {
+	return GetTenant(ctx)
+}
+
+// WithTenantTranslationFile sets the message file paths that override the messages for the tenant.
+//
+// The messages of the tenant are looked up first, then the messages of the bundle.
+func WithTenantTranslationFile(tenant string, paths ...string) Option {
+	return func(c *config) {
+		c.tenantTranslations = append(c.tenantTranslations, tenantTranslation{tenant: tenant, files: paths})
+	}
+}
+
+// WithTenantTranslationFSFile sets the message file paths that override the messages for the tenant.
+//
+// It is similar to WithTenantTranslationFile, but it uses embed.FS as file system.
+func WithTenantTranslationFSFile(tenant string, fs embed.FS, paths ...string) Option {
+	return func(c *config) {
+		c.tenantTranslations = append(c.tenantTranslations, tenantTranslation{
+			tenant: tenant,
+			fsFile: &translationFSFile{fs: fs, paths: paths},
+		})
+	}
+}
+
+// WithTenantDir loads the tenant message files from the directory.
+//
+// Every subdirectory is a tenant and contains its message files, e.g. tenants/acme/en.yaml.
+func WithTenantDir(dir string) Option {
+	return func(c *config) {
+		c.tenantDirs = append(c.tenantDirs, dir)
+	}
+}
+
+// WithExtractTenantFunc sets the function that extracts the tenant from the context.
+//
+// The default function returns the tenant set by NewContextWithTenant.
+func WithExtractTenantFunc(extractTenantFunc func(ctx context.Context) string) Option {
+	return func(c *config) {
+		c.extractTenantFunc = extractTenantFunc
+	}
+}
+
+// NewContextWithTenant sets the tenant to the context.
+func NewContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey, tenant)
+}
+
+// GetTenant returns the tenant from the context set by NewContextWithTenant.
+//
+// If the tenant is not found, it returns an empty string.
+func GetTenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantCtxKey).(string)
+	return tenant
+}
+
+// loadTenantBundles loads the message files of the tenants to a bundle per tenant.
+// The ids of the tenant messages that reference other messages are added to the references.
+func loadTenantBundles(tag language.Tag, config *config, references map[string]bool) (map[string]*i18n.Bundle, error) {
+	translations := config.tenantTranslations
+	for _, dir := range config.tenantDirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			files, err := os.ReadDir(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			translation := tenantTranslation{tenant: entry.Name()}
+			for _, file := range files {
+				if !file.IsDir() {
+					translation.files = append(translation.files, filepath.Join(dir, entry.Name(), file.Name()))
+				}
+			}
+			translations = append(translations, translation)
+		}
+	}
+
+	tenants := make(map[string]*i18n.Bundle)
+	for _, translation := range translations {
+		b, ok := tenants[translation.tenant]
+		if !ok {
+			b = i18n.NewBundle(tag)
+			tenants[translation.tenant] = b
+		}
+		var files []*i18n.MessageFile
+		for _, path := range translation.files {
+			buf, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			file, err := i18n.ParseMessageFileBytes(buf, path, config.unmarshalFuncMap)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+		if translation.fsFile != nil {
+			for _, path := range translation.fsFile.paths {
+				buf, err := fs.ReadFile(translation.fsFile.fs, path)
+				if err != nil {
+					return nil, err
+				}
+				file, err := i18n.ParseMessageFileBytes(buf, path, config.unmarshalFuncMap)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, file)
+			}
+		}
+		for i, file := range prepareMessages(files, config.leftDelim, config.rightDelim) {
+			if err := b.AddMessages(file.Tag, files[i].Messages...); err != nil {
+				return nil, err
+			}
+			for _, message := range file.Messages {
+				if len(messageReferences(message, config.leftDelim, config.rightDelim)) > 0 {
+					references[message.ID] = true
+				}
+			}
+		}
+	}
+	return tenants, nil
+}
+
+// syncTenantTags adds the languages of the base bundle to the tenant bundles,
+// so they resolve the requested languages the same way as the base bundle.
+func syncTenantTags(base *i18n.Bundle, tenants map[string]*i18n.Bundle) error {
+	for _, b := range tenants {
+		for _, tag := range base.LanguageTags() {
+			if err := b.AddMessages(tag); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// localizeTenant looks up the message in the tenant bundle before the base bundle.
+//
+// The requested languages are looked up in the tenant and the base bundle before the default language.
+func localizeTenant(tenant, base *i18n.Localizer, lc *i18n.LocalizeConfig) (string, language.Tag, error) {
+	tenantConfig := *lc
+	tenantConfig.DefaultMessage = nil
+	tenantMessage, tenantTag, tenantErr := tenant.LocalizeWithTag(&tenantConfig)
+	if tenantMessage != "" && tenantErr == nil {
+		return tenantMessage, tenantTag, nil
+	}
+
+	message, tag, err := base.LocalizeWithTag(lc)
+	if message != "" && err == nil {
+		return message, tag, nil
+	}
+	if tenantMessage != "" {
+		return tenantMessage, tenantTag, tenantErr
+	}
+	return message, tag, err
+}
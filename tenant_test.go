@@ -0,0 +1,97 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTenant(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+		i18n.WithTenantDir("testdata/tenants"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		tenant          string
+		language        string
+		expectedMessage string
+	}{
+		{
+			name:            "without tenant",
+			expectedMessage: "Cart",
+		},
+		{
+			name:            "tenant override",
+			tenant:          "acme",
+			expectedMessage: "Basket",
+		},
+		{
+			name:            "tenant without override in language",
+			tenant:          "acme",
+			language:        "id",
+			expectedMessage: "Keranjang",
+		},
+		{
+			name:            "tenant override in default language",
+			tenant:          "acme",
+			language:        "es",
+			expectedMessage: "Basket",
+		},
+		{
+			name:            "tenant override in language",
+			tenant:          "globex",
+			language:        "id",
+			expectedMessage: "Troli",
+		},
+		{
+			name:            "tenant without override",
+			tenant:          "globex",
+			expectedMessage: "Cart",
+		},
+		{
+			name:            "unknown tenant",
+			tenant:          "initech",
+			expectedMessage: "Cart",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.tenant != "" {
+				ctx = i18n.NewContextWithTenant(ctx, tc.tenant)
+			}
+			if tc.language != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.language)
+			}
+			assert.Equal(t, tc.expectedMessage, i18n.TCtx(ctx, "cart"))
+		})
+	}
+}
+
+func TestTenantWithExtractTenantFunc(t *testing.T) {
+	type tenantKey struct{}
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml"),
+		i18n.WithTenantTranslationFile("acme", "testdata/tenants/acme/en.yaml"),
+		i18n.WithExtractTenantFunc(func(ctx context.Context) string {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			return tenant
+		}),
+	)
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	assert.Equal(t, "Basket", i18n.TCtx(ctx, "cart"))
+	assert.Equal(t, "Cart", i18n.TCtx(context.Background(), "cart"))
+}
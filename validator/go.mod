@@ -0,0 +1,25 @@
+module github.com/ahmadfaizk/i18n/validator
+
+go 1.26.0
+
+require (
+	github.com/ahmadfaizk/i18n v0.1.0
+	github.com/go-playground/validator/v10 v10.30.5
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/text v0.42.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gabriel-vasile/mimetype v1.4.15 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/leodido/go-urn v1.5.0 // indirect
+	github.com/nicksnyder/go-i18n/v2 v2.4.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.57.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+)
+
+replace github.com/ahmadfaizk/i18n => ../
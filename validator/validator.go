@@ -0,0 +1,108 @@
+// Package i18nvalidator translates the validation errors of go-playground/validator.
+//
+// The message of a validation tag is looked up by the catalog ID "validation.<tag>", e.g. "validation.required",
+// and the field name by "field.<name>". The name is validator.FieldError.Field, which is the Go struct field name,
+// e.g. "field.Email", unless a tag name function is registered with validator.Validate.RegisterTagNameFunc,
+// e.g. to use the json tag and look up "field.email". The messages receive the template data:
+//
+//	field  the translated field name
+//	param  the parameter of the tag, e.g. 8 for min=8
+//	value  the value of the field
+//	tag    the validation tag
+//
+// Example catalog:
+//
+//	validation.required: "{{.field}} is required"
+//	validation.min: "{{.field}} must be at least {{.param}} characters"
+//	field.Email: "Email address"
+package i18nvalidator
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ahmadfaizk/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+type config struct {
+	messagePrefix string
+	fieldPrefix   string
+}
+
+// Option is the option for Translate.
+type Option func(*config)
+
+func newConfig(opts ...Option) *config {
+	c := &config{
+		messagePrefix: "validation.",
+		fieldPrefix:   "field.",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithMessagePrefix sets the prefix of the message ID of the validation tags. The default is "validation.".
+func WithMessagePrefix(prefix string) Option {
+	return func(c *config) {
+		c.messagePrefix = prefix
+	}
+}
+
+// WithFieldPrefix sets the prefix of the message ID of the field names. The default is "field.".
+func WithFieldPrefix(prefix string) Option {
+	return func(c *config) {
+		c.fieldPrefix = prefix
+	}
+}
+
+// Translate returns the localized messages of the validation errors in the language of the context.
+//
+// The messages are keyed by the field namespace without the root struct, e.g. "Email" or "Address.City".
+// If err is not a validator.ValidationErrors, it returns nil.
+// A missing tag message falls back to the message of the validator and a missing field name to the field name.
+//
+// Example:
+//
+//	if err := validate.Struct(req); err != nil {
+//		messages := i18nvalidator.Translate(r.Context(), err)
+//		_ = json.NewEncoder(w).Encode(messages)
+//	}
+func Translate(ctx context.Context, err error, opts ...Option) map[string]string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	messages := make(map[string]string, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		messages[fieldKey(fieldError)] = TranslateFieldError(ctx, fieldError, opts...)
+	}
+	return messages
+}
+
+// TranslateFieldError returns the localized message of the field error in the language of the context.
+func TranslateFieldError(ctx context.Context, fieldError validator.FieldError, opts ...Option) string {
+	c := newConfig(opts...)
+	field := i18n.GetCtx(ctx, c.fieldPrefix+fieldError.Field(), i18n.Default(fieldError.Field()))
+	return i18n.GetCtx(ctx, c.messagePrefix+fieldError.Tag(),
+		i18n.Default(fieldError.Error()),
+		i18n.Params{
+			"field": field,
+			"param": fieldError.Param(),
+			"value": fieldError.Value(),
+			"tag":   fieldError.Tag(),
+		},
+	)
+}
+
+func fieldKey(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
@@ -0,0 +1,90 @@
+package i18nvalidator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahmadfaizk/i18n"
+	i18nvalidator "github.com/ahmadfaizk/i18n/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+type address struct {
+	City string `validate:"required"`
+}
+
+type signUp struct {
+	Email    string `validate:"required,email"`
+	Password string `validate:"min=8"`
+	Age      int    `validate:"gte=18"`
+	Address  address
+}
+
+func TestTranslate(t *testing.T) {
+	err := i18n.Init(language.English,
+		i18n.WithUnmarshalFunc("yaml", yaml.Unmarshal),
+		i18n.WithTranslationFile("testdata/en.yaml", "testdata/id.yaml"),
+	)
+	require.NoError(t, err)
+
+	validate := validator.New()
+	validationErr := validate.Struct(signUp{Email: "john", Password: "secret", Age: 17})
+	require.Error(t, validationErr)
+
+	testCases := []struct {
+		name     string
+		lang     string
+		opts     []i18nvalidator.Option
+		expected map[string]string
+	}{
+		{
+			name: "default language",
+			expected: map[string]string{
+				"Email":        "Email address must be a valid email address",
+				"Password":     "Password must be at least 8 characters",
+				"Age":          "Key: 'signUp.Age' Error:Field validation for 'Age' failed on the 'gte' tag",
+				"Address.City": "City is required",
+			},
+		},
+		{
+			name: "indonesian",
+			lang: "id",
+			expected: map[string]string{
+				"Email":        "Alamat email harus berupa alamat email yang valid",
+				"Password":     "Kata sandi minimal 8 karakter",
+				"Age":          "Key: 'signUp.Age' Error:Field validation for 'Age' failed on the 'gte' tag",
+				"Address.City": "Kota wajib diisi",
+			},
+		},
+		{
+			name: "custom prefix",
+			lang: "id",
+			opts: []i18nvalidator.Option{i18nvalidator.WithMessagePrefix("invalid."), i18nvalidator.WithFieldPrefix("label.")},
+			expected: map[string]string{
+				"Email":        "Key: 'signUp.Email' Error:Field validation for 'Email' failed on the 'email' tag",
+				"Password":     "Key: 'signUp.Password' Error:Field validation for 'Password' failed on the 'min' tag",
+				"Age":          "Key: 'signUp.Age' Error:Field validation for 'Age' failed on the 'gte' tag",
+				"Address.City": "Key: 'signUp.Address.City' Error:Field validation for 'City' failed on the 'required' tag",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.lang != "" {
+				ctx = i18n.NewContextWithLanguage(ctx, tc.lang)
+			}
+			assert.Equal(t, tc.expected, i18nvalidator.Translate(ctx, validationErr, tc.opts...))
+		})
+	}
+
+	t.Run("not validation errors", func(t *testing.T) {
+		assert.Nil(t, i18nvalidator.Translate(context.Background(), errors.New("invalid")))
+	})
+}